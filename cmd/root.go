@@ -3,9 +3,16 @@ package cmd
 import (
 	"os"
 
+	"github.com/dizzyfool/genna/generators/docs"
+	"github.com/dizzyfool/genna/generators/jsonschema"
 	"github.com/dizzyfool/genna/generators/model"
 	"github.com/dizzyfool/genna/generators/named"
+	"github.com/dizzyfool/genna/generators/pgx"
+	"github.com/dizzyfool/genna/generators/proto"
+	"github.com/dizzyfool/genna/generators/repo"
 	"github.com/dizzyfool/genna/generators/search"
+	"github.com/dizzyfool/genna/generators/snapshot"
+	"github.com/dizzyfool/genna/generators/typescript"
 	"github.com/dizzyfool/genna/generators/validate"
 
 	"github.com/spf13/cobra"
@@ -33,6 +40,13 @@ func init() {
 		search.CreateCommand(),
 		validate.CreateCommand(),
 		named.CreateCommand(),
+		repo.CreateCommand(),
+		pgx.CreateCommand(),
+		proto.CreateCommand(),
+		jsonschema.CreateCommand(),
+		typescript.CreateCommand(),
+		docs.CreateCommand(),
+		snapshot.CreateCommand(),
 	)
 }
 
@@ -0,0 +1,399 @@
+// Package composite scans and formats values of postgres composite types in text format.
+// It is used by Scan and Value methods of generated composite types.
+package composite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are formats of date and time types in composite values
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// escaper escapes field of composite value, every field is quoted
+var escaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+
+// Scan sets fields of composite type from composite value in text format,
+// dest are pointers to fields in order of composite type, NULL value or NULL field sets zero value
+func Scan(src interface{}, dest ...interface{}) error {
+	var text string
+	switch value := src.(type) {
+	case nil:
+		return scanFields(nil, dest)
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	default:
+		return fmt.Errorf("unsupported type %T of composite value", src)
+	}
+
+	fields, err := parse(text)
+	if err != nil {
+		return err
+	}
+
+	return scanFields(fields, dest)
+}
+
+// Value gets composite value in text format, fields are values in order of composite type, nil pointers are NULL
+func Value(fields ...interface{}) (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, field := range fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		text, ok, err := format(field)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			b.WriteString("\"" + escaper.Replace(text) + "\"")
+		}
+	}
+	b.WriteByte(')')
+
+	return b.String(), nil
+}
+
+// Array gets sql.Scanner and driver.Valuer of array of composite values, dest is a pointer to slice,
+// e.g. composite.Array(&m.History), it is used with drivers which scan only arrays of known types
+func Array(dest interface{}) interface {
+	sql.Scanner
+	driver.Valuer
+} {
+	return array{dest: dest}
+}
+
+type array struct {
+	dest interface{}
+}
+
+// Scan implements sql.Scanner
+func (a array) Scan(src interface{}) error {
+	value := reflect.ValueOf(a.dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("destination %T is not a pointer to slice", a.dest)
+	}
+
+	switch v := src.(type) {
+	case nil:
+		value.Elem().Set(reflect.Zero(value.Elem().Type()))
+		return nil
+	case string:
+		return scanText(value.Elem(), v)
+	case []byte:
+		return scanText(value.Elem(), string(v))
+	}
+
+	return fmt.Errorf("unsupported type %T of array value", src)
+}
+
+// Value implements driver.Valuer
+func (a array) Value() (driver.Value, error) {
+	text, ok, err := format(a.dest)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	return text, nil
+}
+
+// parse splits composite value in text format into fields, nil field is NULL
+func parse(text string) ([]*string, error) {
+	if len(text) < 2 || text[0] != '(' || text[len(text)-1] != ')' {
+		return nil, fmt.Errorf("%q is not a composite value", text)
+	}
+
+	var (
+		fields []*string
+		field  strings.Builder
+		quoted bool
+		null   = true
+	)
+
+	for i := 1; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '"' && quoted && i+1 < len(text) && text[i+1] == '"':
+			// doubled quote is a quote inside quoted field
+			field.WriteByte('"')
+			i++
+		case c == '"':
+			quoted, null = !quoted, false
+		case c == '\\' && i+1 < len(text):
+			i++
+			field.WriteByte(text[i])
+		case !quoted && (c == ',' || c == ')'):
+			if null {
+				fields = append(fields, nil)
+			} else {
+				value := field.String()
+				fields = append(fields, &value)
+			}
+			field.Reset()
+			null = true
+		default:
+			field.WriteByte(c)
+			null = false
+		}
+	}
+
+	return fields, nil
+}
+
+// parseArray splits array in text format into elements, nested arrays are kept as is, nil element is NULL
+func parseArray(text string) ([]*string, error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("%q is not an array value", text)
+	}
+	if text == "{}" {
+		return nil, nil
+	}
+
+	var (
+		elements []*string
+		element  strings.Builder
+		quoted   bool
+		wasQuote bool
+		depth    int
+	)
+
+	for i := 1; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text):
+			// nested arrays are parsed again, so escapes are kept in them
+			if depth > 0 {
+				element.WriteByte(c)
+			}
+			i++
+			element.WriteByte(text[i])
+		case c == '"':
+			if depth > 0 {
+				element.WriteByte(c)
+			}
+			quoted, wasQuote = !quoted, true
+		case quoted:
+			element.WriteByte(c)
+		case c == '{':
+			depth++
+			element.WriteByte(c)
+		case c == '}' && depth > 0:
+			depth--
+			element.WriteByte(c)
+		case depth > 0:
+			element.WriteByte(c)
+		case c == ',' || c == '}':
+			value := element.String()
+			if !wasQuote && value == "NULL" {
+				elements = append(elements, nil)
+			} else {
+				elements = append(elements, &value)
+			}
+			element.Reset()
+			wasQuote = false
+		default:
+			element.WriteByte(c)
+		}
+	}
+
+	return elements, nil
+}
+
+// scanFields sets dest from text values of fields, missing and NULL fields set zero values
+func scanFields(fields []*string, dest []interface{}) error {
+	for i, d := range dest {
+		value := reflect.ValueOf(d)
+		if value.Kind() != reflect.Ptr || value.IsNil() {
+			return fmt.Errorf("destination %d is not a pointer", i)
+		}
+
+		if err := scanValue(value.Elem(), fields, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanValue sets value from text value of i-th field or element
+func scanValue(value reflect.Value, fields []*string, i int) error {
+	if i >= len(fields) || fields[i] == nil {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+
+	if value.Kind() == reflect.Ptr {
+		value.Set(reflect.New(value.Type().Elem()))
+		value = value.Elem()
+	}
+
+	return scanText(value, *fields[i])
+}
+
+// scanText sets value from text, nested composite values are scanned by sql.Scanner of their types
+func scanText(value reflect.Value, text string) error {
+	switch field := value.Addr().Interface().(type) {
+	case sql.Scanner:
+		return field.Scan(text)
+	case *time.Time:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				*field = t
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time value %q", text)
+	case *[]byte:
+		if !strings.HasPrefix(text, "\\x") {
+			*field = []byte(text)
+			return nil
+		}
+		b, err := hex.DecodeString(text[2:])
+		if err != nil {
+			return err
+		}
+		*field = b
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		value.SetBool(text == "t" || text == "true")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		elements, err := parseArray(text)
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(value.Type(), len(elements), len(elements))
+		for i := range elements {
+			if err := scanValue(slice.Index(i), elements, i); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+	case reflect.Interface:
+		value.Set(reflect.ValueOf(text))
+	default:
+		return fmt.Errorf("unsupported type %s of composite field", value.Type())
+	}
+
+	return nil
+}
+
+// format gets text of field, false is returned for NULL
+func format(field interface{}) (string, bool, error) {
+	value := reflect.ValueOf(field)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", false, nil
+		}
+		value = value.Elem()
+		field = value.Interface()
+	}
+
+	// bytea field, bytes from valuer are used as is
+	if b, ok := field.([]byte); ok {
+		if b == nil {
+			return "", false, nil
+		}
+		return "\\x" + hex.EncodeToString(b), true, nil
+	}
+
+	if valuer, ok := field.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil || v == nil {
+			return "", false, err
+		}
+		field, value = v, reflect.ValueOf(v)
+	}
+
+	switch v := field.(type) {
+	case nil:
+		return "", false, nil
+	case time.Time:
+		return v.Format(timeLayouts[0]), true, nil
+	case []byte:
+		return string(v), true, nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), true, nil
+	case reflect.Slice:
+		if value.IsNil() {
+			return "", false, nil
+		}
+		text, err := formatArray(value)
+		return text, true, err
+	}
+
+	return "", false, fmt.Errorf("unsupported type %T of composite field", field)
+}
+
+// formatArray gets array in text format, nested arrays are not quoted
+func formatArray(value reflect.Value) (string, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i != value.Len(); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		item := value.Index(i)
+		if item.Kind() == reflect.Slice && item.Type().Elem().Kind() != reflect.Uint8 {
+			text, err := formatArray(item)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(text)
+			continue
+		}
+
+		text, ok, err := format(item.Interface())
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			b.WriteString("NULL")
+			continue
+		}
+		b.WriteString("\"" + escaper.Replace(text) + "\"")
+	}
+	b.WriteByte('}')
+
+	return b.String(), nil
+}
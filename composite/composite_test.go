@@ -0,0 +1,216 @@
+package composite
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type point struct {
+	X, Y float64
+}
+
+func (p *point) Scan(src interface{}) error {
+	return Scan(src, &p.X, &p.Y)
+}
+
+func (p point) Value() (driver.Value, error) {
+	return Value(p.X, p.Y)
+}
+
+type address struct {
+	Street   string
+	Floor    *int
+	Verified bool
+	Since    time.Time
+	Location point
+	Tags     []string
+	Matrix   [][]int
+	Data     []byte
+}
+
+func (a *address) Scan(src interface{}) error {
+	return Scan(src, &a.Street, &a.Floor, &a.Verified, &a.Since, &a.Location, &a.Tags, &a.Matrix, &a.Data)
+}
+
+func (a address) Value() (driver.Value, error) {
+	return Value(a.Street, a.Floor, a.Verified, a.Since, a.Location, a.Tags, a.Matrix, a.Data)
+}
+
+func TestScan(t *testing.T) {
+	floor := 3
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    address
+		wantErr bool
+	}{
+		{
+			name: "Should scan quoted fields",
+			src:  `("Main ""st"" \\ 1",3,t,"2020-01-02 10:00:00+00","(1.5,-2)","{a,""b c"",NULL}","{{1,2},{3,4}}","\\x0aff")`,
+			want: address{
+				Street:   `Main "st" \ 1`,
+				Floor:    &floor,
+				Verified: true,
+				Since:    time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+				Location: point{X: 1.5, Y: -2},
+				Tags:     []string{"a", "b c", ""},
+				Matrix:   [][]int{{1, 2}, {3, 4}},
+				Data:     []byte{0x0a, 0xff},
+			},
+		},
+		{
+			name: "Should scan NULL fields as zero values",
+			src:  []byte(`(x,,,,,,,)`),
+			want: address{Street: "x"},
+		},
+		{
+			name: "Should scan NULL as zero value",
+			src:  nil,
+			want: address{},
+		},
+		{
+			name:    "Should fail on not composite value",
+			src:     "x",
+			wantErr: true,
+		},
+		{
+			name:    "Should fail on invalid field",
+			src:     "(x,y,,,,,,)",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := address{Street: "old", Tags: []string{"old"}}
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !got.Since.Equal(tt.want.Since) {
+				t.Errorf("Scan() since = %v, want %v", got.Since, tt.want.Since)
+			}
+			got.Since, tt.want.Since = time.Time{}, time.Time{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	floor := 3
+
+	tests := []struct {
+		name  string
+		value address
+		want  driver.Value
+	}{
+		{
+			name: "Should format every field quoted",
+			value: address{
+				Street:   `Main "st" \ 1`,
+				Floor:    &floor,
+				Verified: true,
+				Since:    time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+				Location: point{X: 1.5, Y: -2},
+				Tags:     []string{"a", `b "c",d`},
+				Matrix:   [][]int{{1, 2}, {3}},
+				Data:     []byte{0x0a, 0xff},
+			},
+			want: `("Main \"st\" \\ 1","3","true","2020-01-02 10:00:00Z","(\"1.5\",\"-2\")","{\"a\",\"b \\\"c\\\",d\"}","{{\"1\",\"2\"},{\"3\"}}","\\x0aff")`,
+		},
+		{
+			name:  "Should format nil fields as NULL",
+			value: address{},
+			want:  `("",,"false","0001-01-01 00:00:00Z","(\"0\",\"0\")",,,)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value.Value()
+			if err != nil {
+				t.Errorf("Value() error = %v", err)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("Value() = %v, want %v", got, tt.want)
+			}
+
+			var scanned address
+			if err := scanned.Scan(got); err != nil {
+				t.Errorf("Scan() of Value() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(scanned.Tags, tt.value.Tags) || !reflect.DeepEqual(scanned.Matrix, tt.value.Matrix) || scanned.Street != tt.value.Street {
+				t.Errorf("Scan() of Value() = %#v, want %#v", scanned, tt.value)
+			}
+		})
+	}
+}
+
+func TestArray(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    []point
+		wantErr bool
+	}{
+		{
+			name: "Should scan array of composite values",
+			src:  `{"(1,2)",NULL,"(3.5,-4)"}`,
+			want: []point{{X: 1, Y: 2}, {}, {X: 3.5, Y: -4}},
+		},
+		{
+			name: "Should scan empty array",
+			src:  []byte(`{}`),
+			want: []point{},
+		},
+		{
+			name: "Should scan NULL as nil",
+			src:  nil,
+			want: nil,
+		},
+		{
+			name:    "Should fail on not array value",
+			src:     `(1,2)`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []point{{X: 9}}
+			err := Array(&got).Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Array().Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Array().Scan() = %#v, want %#v", got, tt.want)
+			}
+
+			value, err := Array(&got).Value()
+			if err != nil {
+				t.Errorf("Array().Value() error = %v", err)
+				return
+			}
+
+			var scanned []point
+			if err := Array(&scanned).Scan(value); err != nil || !reflect.DeepEqual(scanned, tt.want) {
+				t.Errorf("Array().Scan() of Value() = %#v, %v, want %#v", scanned, err, tt.want)
+			}
+		})
+	}
+}
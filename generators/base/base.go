@@ -2,12 +2,15 @@ package base
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"html/template"
+	"io/ioutil"
 	"log"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/dizzyfool/genna/lib"
 	"github.com/dizzyfool/genna/model"
@@ -17,21 +20,48 @@ import (
 )
 
 const (
+	// ConfigFile is basic flag for yaml config file
+	ConfigFile = "config"
+
 	// Conn is connection string (-c) basic flag
 	Conn = "conn"
 
+	// DDL is sql file basic flag used instead of connection string
+	DDL = "ddl"
+
+	// FromSnapshot is snapshot file basic flag used instead of connection string
+	FromSnapshot = "from-snapshot"
+
 	// Output is output filename (-o) basic flag
 	Output = "output"
 
+	// MultiFile is basic flag for writing every entity to its own file
+	MultiFile = "multi-file"
+
+	// SchemaPkg is basic flag for writing every schema to its own package
+	SchemaPkg = "schema-package"
+
+	// Check is basic flag for comparing generated code with existing files instead of writing
+	Check = "check"
+
+	// Template is basic flag for user template file or directory used instead of built-in template
+	Template = "template"
+
 	// Tables is basic flag (-t) for tables to generate
 	Tables = "tables"
 
 	// FollowFKs is basic flag (-f) for generate foreign keys models for selected tables
 	FollowFKs = "follow-fk"
 
+	// Views is basic flag for generate models for views and materialized views
+	Views = "views"
+
 	// Go-PG version to use
 	GoPgVer = "gopg"
 
+	// Target is basic flag for orm of generated code
+	Target = "target"
+
 	// Package for model files
 	Pkg = "pkg"
 
@@ -40,6 +70,18 @@ const (
 
 	// custom types flag
 	customTypesFlag = "custom-types"
+
+	// numeric type flag
+	decimalFlag = "decimal"
+)
+
+const (
+	// DecimalFloat maps numeric to float64
+	DecimalFloat = "float"
+	// DecimalShopspring maps numeric to github.com/shopspring/decimal
+	DecimalShopspring = "shopspring"
+	// DecimalString maps numeric to string
+	DecimalString = "string"
 )
 
 // Gen is interface for all generators
@@ -53,18 +95,69 @@ type Gen interface {
 // Packer is a function that compile entities to package
 type Packer func(entities []model.Entity) (interface{}, error)
 
+// GeneratedMarker is a first line of files generated in multi-file mode
+// used to find stale files which should be removed
+const GeneratedMarker = "// Code generated by genna. DO NOT EDIT."
+
+const (
+	// TargetGoPG is a target for github.com/go-pg/pg
+	TargetGoPG = "go-pg"
+	// TargetBun is a target for github.com/uptrace/bun
+	TargetBun = "bun"
+)
+
+// ErrDrift is returned in check mode if generated code differs from existing files
+var ErrDrift = errors.New("generated code differs from existing files")
+
+// MultiTemplate is a set of templates for multi-file output
+type MultiTemplate struct {
+	// Name is a name of shared file and suffix of entities files, e.g. search.go & user_search.go
+	Name string
+
+	// Shared is a template for file with code common for all entities
+	Shared string
+
+	// Entity is a template for file of every entity
+	Entity string
+}
+
 // Options is common options for all generators
 type Options struct {
 	// URL connection string
 	URL string
 
+	// DDL file path, used instead of URL if set
+	DDL string
+
+	// Snapshot file path, used instead of URL if set
+	Snapshot string
+
 	// Output file path
+	// Output directory if MultiFile is set
 	Output string
 
+	// Write every entity to its own file
+	MultiFile bool
+
+	// Write every schema to its own package in sub directory of output directory
+	SchemaPackage bool
+
+	// Compare generated code with existing files instead of writing
+	// differences are printed as unified diff and ErrDrift is returned
+	Check bool
+
+	// User template file used instead of built-in template
+	// Directory with shared.tmpl and entity.tmpl if MultiFile is set
+	Template string
+
 	// List of Tables to generate
 	// Default []string{"public.*"}
 	Tables []string
 
+	// Generate models for views and materialized views too
+	// Such entities are read-only
+	Views bool
+
 	// Generate model for foreign keys,
 	// even if Tables not listed in Tables param
 	// will not generate fks if schema not listed
@@ -73,8 +166,15 @@ type Options struct {
 	// go-pg version
 	GoPgVer int
 
+	// ORM of generated code, go-pg or bun
+	// Default go-pg
+	Target string
+
 	// Custom types goes here
 	CustomTypes model.CustomTypeMapping
+
+	// Per-table and per-column overrides from config file
+	Overrides Overrides
 }
 
 // Def sets default options if empty
@@ -87,14 +187,40 @@ func (o *Options) Def() {
 		o.GoPgVer = 10
 	}
 
+	if o.Target == "" {
+		o.Target = TargetGoPG
+	}
+
 	if o.CustomTypes == nil {
 		o.CustomTypes = model.CustomTypeMapping{}
 	}
 }
 
+// AddDecimal adds custom type for numeric, explicitly set custom type is kept
+func AddDecimal(customTypes model.CustomTypeMapping, decimal string) error {
+	if customTypes.Has(model.TypePGNumeric) {
+		return nil
+	}
+
+	switch decimal {
+	case "", DecimalFloat:
+	case DecimalShopspring:
+		customTypes.Add(model.TypePGNumeric, model.TypeDecimal, model.DecimalImport)
+	case DecimalString:
+		customTypes.Add(model.TypePGNumeric, model.TypeString, "")
+	default:
+		return fmt.Errorf("decimal type %s not supported", decimal)
+	}
+
+	return nil
+}
+
 // Generator is base generator used in other generators
 type Generator struct {
 	genna.Genna
+
+	// Check compares generated code with existing files instead of writing
+	Check bool
 }
 
 // NewGenerator creates generator
@@ -104,46 +230,97 @@ func NewGenerator(url string) Generator {
 	}
 }
 
+// NewGeneratorFromOptions creates generator which reads DDL or snapshot file if set or database otherwise
+func NewGeneratorFromOptions(options Options) Generator {
+	generator := Generator{
+		Genna: genna.New(options.URL, nil),
+		Check: options.Check,
+	}
+
+	switch {
+	case options.DDL != "":
+		generator.Genna = genna.NewFromDDL(options.DDL, nil)
+	case options.Snapshot != "":
+		generator.Genna = genna.NewFromSnapshot(options.Snapshot, nil)
+	}
+
+	return generator
+}
+
 // AddFlags adds basic flags to command
 func AddFlags(command *cobra.Command) {
 	flags := command.Flags()
 
+	flags.String(ConfigFile, "", "yaml config file, "+DefaultConfig+" from working directory is used if exists\nflags override config values")
 	flags.StringP(Conn, "c", "", "connection string to your postgres database")
-	if err := command.MarkFlagRequired(Conn); err != nil {
-		panic(err)
-	}
+	flags.String(DDL, "", "sql file with schema DDL to read instead of database")
+	flags.String(FromSnapshot, "", "snapshot file created by snapshot command to read instead of database")
 
-	flags.StringP(Output, "o", "", "output file name")
-	if err := command.MarkFlagRequired(Output); err != nil {
-		panic(err)
-	}
+	flags.StringP(Output, "o", "", "output file name, required")
+	flags.Bool(MultiFile, false, "write every entity to its own file, output should be a directory")
+	flags.Bool(SchemaPkg, false, "write every schema to its own package in sub directory named after schema\nimport path of packages is detected by go.mod")
 
 	flags.StringP(Pkg, "p", "", "package for model files. if not set last folder name in output path will be used")
+	flags.Bool(Check, false, "compare generated code with existing files instead of writing\nprints diff and exits with non-zero code if they differ")
+	flags.String(Template, "", "template file to use instead of built-in one\nuse directory with shared.tmpl and entity.tmpl for multi-file output")
 
 	flags.StringSliceP(Tables, "t", []string{"public.*"}, "table names for model generation separated by comma\nuse 'schema_name.*' to generate model for every table in model")
 	flags.BoolP(FollowFKs, "f", false, "generate models for foreign keys, even if it not listed in Tables\n")
+	flags.Bool(Views, false, "generate read-only models for views and materialized views\n")
 
 	flags.Bool(uuidFlag, false, "use github.com/google/uuid as type for uuid")
+	flags.String(decimalFlag, DecimalFloat, "go type for numeric: float, shopspring (github.com/shopspring/decimal) or string\nfloat64 loses precision of numeric values")
 
 	flags.StringSlice(customTypesFlag, []string{}, "set custom types separated by comma\nformat: <postgresql_type>:<go_import>.<go_type>\nexamples: uuid:github.com/google/uuid.UUID,point:src/model.Point,bytea:string\n")
 
 	flags.IntP(GoPgVer, "g", 10, "specify go-pg version (8, 9 and 10 are supported)")
+	flags.String(Target, TargetGoPG, "orm of generated code: go-pg or bun")
 
 	return
 }
 
 // ReadFlags reads basic flags from command
-func ReadFlags(command *cobra.Command) (conn, output, pkg string, tables []string, followFKs bool, gopgVer int, customTypes model.CustomTypeMapping, err error) {
+func ReadFlags(command *cobra.Command) (options Options, pkg string, err error) {
 	var customTypesStrings []string
-	uuid := false
+	uuid, decimal := false, ""
+
+	if options.Overrides, err = ApplyConfig(command); err != nil {
+		return
+	}
 
 	flags := command.Flags()
 
-	if conn, err = flags.GetString(Conn); err != nil {
+	if options.URL, err = flags.GetString(Conn); err != nil {
+		return
+	}
+
+	if options.DDL, err = flags.GetString(DDL); err != nil {
+		return
+	}
+
+	if options.Snapshot, err = flags.GetString(FromSnapshot); err != nil {
+		return
+	}
+
+	if options.URL == "" && options.DDL == "" && options.Snapshot == "" {
+		err = fmt.Errorf("connection string, DDL or snapshot file is required")
+		return
+	}
+
+	if options.Output, err = flags.GetString(Output); err != nil {
+		return
+	}
+
+	if options.Output == "" {
+		err = fmt.Errorf("output file is required")
 		return
 	}
 
-	if output, err = flags.GetString(Output); err != nil {
+	if options.MultiFile, err = flags.GetBool(MultiFile); err != nil {
+		return
+	}
+
+	if options.SchemaPackage, err = flags.GetBool(SchemaPkg); err != nil {
 		return
 	}
 
@@ -151,19 +328,34 @@ func ReadFlags(command *cobra.Command) (conn, output, pkg string, tables []strin
 		return
 	}
 
+	if options.Check, err = flags.GetBool(Check); err != nil {
+		return
+	}
+
+	if options.Template, err = flags.GetString(Template); err != nil {
+		return
+	}
+
 	if strings.Trim(pkg, " ") == "" {
-		pkg = path.Base(path.Dir(output))
+		pkg = path.Base(path.Dir(options.Output))
+		if options.MultiFile {
+			pkg = path.Base(options.Output)
+		}
+	}
+
+	if options.Tables, err = flags.GetStringSlice(Tables); err != nil {
+		return
 	}
 
-	if tables, err = flags.GetStringSlice(Tables); err != nil {
+	if options.FollowFKs, err = flags.GetBool(FollowFKs); err != nil {
 		return
 	}
 
-	if followFKs, err = flags.GetBool(FollowFKs); err != nil {
+	if options.Views, err = flags.GetBool(Views); err != nil {
 		return
 	}
 
-	if gopgVer, err = flags.GetInt(GoPgVer); err != nil {
+	if options.GoPgVer, err = flags.GetInt(GoPgVer); err != nil {
 		return
 	}
 
@@ -171,7 +363,7 @@ func ReadFlags(command *cobra.Command) (conn, output, pkg string, tables []strin
 		return
 	}
 
-	if customTypes, err = model.ParseCustomTypes(customTypesStrings); err != nil {
+	if options.CustomTypes, err = model.ParseCustomTypes(customTypesStrings); err != nil {
 		return
 	}
 
@@ -179,12 +371,29 @@ func ReadFlags(command *cobra.Command) (conn, output, pkg string, tables []strin
 		return
 	}
 
-	if uuid && !customTypes.Has(model.TypePGUuid) {
-		customTypes.Add(model.TypePGUuid, "uuid.UUID", "github.com/google/uuid")
+	if uuid && !options.CustomTypes.Has(model.TypePGUuid) {
+		options.CustomTypes.Add(model.TypePGUuid, "uuid.UUID", "github.com/google/uuid")
 	}
 
-	if gopgVer < 8 && gopgVer > 10 {
-		err = fmt.Errorf("go-pg version %d not supported", gopgVer)
+	if decimal, err = flags.GetString(decimalFlag); err != nil {
+		return
+	}
+
+	if err = AddDecimal(options.CustomTypes, decimal); err != nil {
+		return
+	}
+
+	if options.GoPgVer < 8 && options.GoPgVer > 10 {
+		err = fmt.Errorf("go-pg version %d not supported", options.GoPgVer)
+		return
+	}
+
+	if options.Target, err = flags.GetString(Target); err != nil {
+		return
+	}
+
+	if options.Target != TargetGoPG && options.Target != TargetBun {
+		err = fmt.Errorf("target %s not supported", options.Target)
 		return
 	}
 
@@ -192,42 +401,309 @@ func ReadFlags(command *cobra.Command) (conn, output, pkg string, tables []strin
 }
 
 // Generate runs whole generation process
-func (g Generator) Generate(tables []string, followFKs, useSQLNulls bool, output, tmpl string, packer Packer, goPGVer int, customTypes model.CustomTypeMapping) error {
-	entities, err := g.Read(tables, followFKs, useSQLNulls, goPGVer, customTypes)
+func (g Generator) Generate(options Options, useSQLNulls bool, tmpl string, packer Packer) error {
+	if options.Template != "" {
+		custom, err := ReadTemplate(options.Template)
+		if err != nil {
+			return err
+		}
+		tmpl = custom
+	}
+
+	entities, err := g.Read(options.Tables, options.Views, options.FollowFKs, useSQLNulls, options.GoPgVer, options.CustomTypes)
 	if err != nil {
 		return fmt.Errorf("read database error: %w", err)
 	}
 
-	return g.GenerateFromEntities(entities, output, tmpl, packer)
+	if err := options.Overrides.Check(entities); err != nil {
+		return err
+	}
+
+	if !options.SchemaPackage {
+		if entities, err = options.Overrides.Apply(entities); err != nil {
+			return err
+		}
+		return g.GenerateFromEntities(entities, options.Output, tmpl, packer)
+	}
+
+	dir, file := path.Split(options.Output)
+	packages, err := schemaPackages(entities, dir)
+	if err != nil {
+		return err
+	}
+
+	drift := false
+	for _, pkg := range packages {
+		// overrides are applied after schema prefixes are removed from names
+		if pkg.Entities, err = options.Overrides.Apply(pkg.Entities); err != nil {
+			return err
+		}
+
+		if err := g.GenerateFromEntities(pkg.Entities, path.Join(dir, pkg.Name, file), tmpl, packer); err != nil {
+			// checking every package before reporting drift
+			if !errors.Is(err, ErrDrift) {
+				return err
+			}
+			drift = true
+		}
+	}
+
+	if drift {
+		return ErrDrift
+	}
+
+	return nil
 }
 
+// GenerateFromEntities writes entities to output file, compares them with output file in check mode
 func (g Generator) GenerateFromEntities(entities []model.Entity, output, tmpl string, packer Packer) error {
-	parsed, err := template.New("base").Parse(tmpl)
+	content, err := execute(entities, tmpl, packer)
+	if err != nil {
+		return err
+	}
+
+	drift, err := g.save(content, output)
+	if err != nil {
+		return err
+	}
+
+	return g.done(len(entities), drift)
+}
+
+// GenerateMulti runs whole generation process writing every entity to its own file
+func (g Generator) GenerateMulti(options Options, useSQLNulls bool, tmpl MultiTemplate, packer Packer) error {
+	if options.Template != "" {
+		custom, err := ReadMultiTemplate(options.Template, tmpl)
+		if err != nil {
+			return err
+		}
+		tmpl = custom
+	}
+
+	entities, err := g.Read(options.Tables, options.Views, options.FollowFKs, useSQLNulls, options.GoPgVer, options.CustomTypes)
+	if err != nil {
+		return fmt.Errorf("read database error: %w", err)
+	}
+
+	if err := options.Overrides.Check(entities); err != nil {
+		return err
+	}
+
+	if !options.SchemaPackage {
+		if entities, err = options.Overrides.Apply(entities); err != nil {
+			return err
+		}
+		return g.GenerateMultiFromEntities(entities, options.Output, tmpl, packer)
+	}
+
+	packages, err := schemaPackages(entities, options.Output)
+	if err != nil {
+		return err
+	}
+
+	drift := false
+	for _, pkg := range packages {
+		// overrides are applied after schema prefixes are removed from names
+		if pkg.Entities, err = options.Overrides.Apply(pkg.Entities); err != nil {
+			return err
+		}
+
+		if err := g.GenerateMultiFromEntities(pkg.Entities, path.Join(options.Output, pkg.Name), tmpl, packer); err != nil {
+			// checking every package before reporting drift
+			if !errors.Is(err, ErrDrift) {
+				return err
+			}
+			drift = true
+		}
+	}
+
+	if drift {
+		return ErrDrift
+	}
+
+	return nil
+}
+
+// schemaPackages groups entities by schema packages created in output directory
+func schemaPackages(entities []model.Entity, output string) ([]SchemaPackage, error) {
+	importPath, err := util.ImportPath(output)
+	if err != nil {
+		return nil, fmt.Errorf("detecting import path of output error: %w", err)
+	}
+
+	return SchemaPackages(entities, importPath), nil
+}
+
+// GenerateMultiFromEntities writes shared file and file for every entity to output directory
+// files generated previously for entities which no longer exist are removed
+func (g Generator) GenerateMultiFromEntities(entities []model.Entity, output string, tmpl MultiTemplate, packer Packer) error {
+	generated := util.NewSet()
+	drift := false
+
+	shared := path.Join(output, tmpl.Name+".go")
+	changed, err := g.generateFile(entities, shared, tmpl.Shared, packer)
+	if err != nil {
+		return err
+	}
+	generated.Add(shared)
+	drift = drift || changed
+
+	for i := range entities {
+		filename := EntityFilename(output, tmpl.Name, entities[i])
+		changed, err := g.generateFile(entities[i:i+1], filename, tmpl.Entity, packer)
+		if err != nil {
+			return err
+		}
+		generated.Add(filename)
+		drift = drift || changed
+	}
+
+	stale, err := staleFiles(output, tmpl.Name, generated)
+	if err != nil {
+		return fmt.Errorf("finding stale files error: %w", err)
+	}
+
+	for _, filename := range stale {
+		if g.Check {
+			fmt.Printf("stale file %s should be removed\n", filename)
+			drift = true
+			continue
+		}
+
+		if err := os.Remove(filename); err != nil {
+			return fmt.Errorf("removing stale files error: %w", err)
+		}
+		log.Printf("removed stale file %s", filename)
+	}
+
+	return g.done(len(entities), drift)
+}
+
+// generateFile writes file with generated marker for multi-file mode
+func (g Generator) generateFile(entities []model.Entity, filename, tmpl string, packer Packer) (bool, error) {
+	content, err := execute(entities, tmpl, packer)
 	if err != nil {
-		return fmt.Errorf("parsing template error: %w", err)
+		return false, err
+	}
+
+	content = append([]byte(GeneratedMarker+"\n"), content...)
+
+	// shared and entity files use only part of imports,
+	// errors are skipped here and reported while formatting
+	if cleaned, err := util.RemoveUnusedImports(content); err == nil {
+		content = cleaned
+	}
+
+	return g.save(content, filename)
+}
+
+// save formats go content and writes it to file
+// in check mode content is compared with file instead, diff is printed and true is returned if they differ
+func (g Generator) save(content []byte, filename string) (bool, error) {
+	if path.Ext(filename) == ".go" {
+		formatted, err := util.Fmt(content)
+		if err != nil {
+			// saving file even if there is fmt errors
+			log.Printf("formatting file %s error: %s", filename, err)
+		}
+		content = formatted
+	}
+
+	if g.Check {
+		return CheckFile(content, filename)
+	}
+
+	if err := util.Save(content, filename); err != nil {
+		return false, fmt.Errorf("saving file error: %w", err)
+	}
+
+	return false, nil
+}
+
+// done logs result of generation, ErrDrift is returned if generated code differs in check mode
+func (g Generator) done(count int, drift bool) error {
+	if drift {
+		return ErrDrift
+	}
+
+	if g.Check {
+		log.Printf("%d models are up to date", count)
+		return nil
+	}
+
+	log.Printf("successfully generated %d models", count)
+
+	return nil
+}
+
+// CheckFile compares content with existing file and prints unified diff if they differ
+// missing file is compared as empty one
+func CheckFile(content []byte, filename string) (bool, error) {
+	existing, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("reading file error: %w", err)
+	}
+
+	diff := util.Diff(filename, filename+" (generated)", existing, content)
+	if diff == "" {
+		return false, nil
+	}
+
+	fmt.Print(diff)
+
+	return true, nil
+}
+
+// EntityFilename gets name of entity file in multi-file mode
+func EntityFilename(output, name string, entity model.Entity) string {
+	return path.Join(output, fmt.Sprintf("%s_%s.go", util.Underscore(entity.GoName), name))
+}
+
+// execute packs entities and executes template
+// text/template is used as generated code is not html, values are written as is
+func execute(entities []model.Entity, tmpl string, packer Packer) ([]byte, error) {
+	parsed, err := template.New("base").Funcs(TemplateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template error: %w", err)
 	}
 
 	pack, err := packer(entities)
 	if err != nil {
-		return fmt.Errorf("packing data error: %w", err)
+		return nil, fmt.Errorf("packing data error: %w", err)
 	}
 
 	var buffer bytes.Buffer
 	if err := parsed.ExecuteTemplate(&buffer, "base", pack); err != nil {
-		return fmt.Errorf("processing model template error: %w", err)
+		return nil, fmt.Errorf("processing model template error: %w", err)
 	}
 
-	saved, err := util.FmtAndSave(buffer.Bytes(), output)
+	return buffer.Bytes(), nil
+}
+
+// staleFiles gets entities files generated by genna before but not generated now
+func staleFiles(output, name string, generated util.Set) ([]string, error) {
+	files, err := filepath.Glob(path.Join(output, "*_"+name+".go"))
 	if err != nil {
-		if !saved {
-			return fmt.Errorf("saving file error: %w", err)
-		}
-		log.Printf("formatting file %s error: %s", output, err)
+		return nil, err
 	}
 
-	log.Printf("successfully generated %d models", len(entities))
+	var stale []string
+	for _, filename := range files {
+		if generated.Exists(filename) {
+			continue
+		}
 
-	return nil
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.HasPrefix(content, []byte(GeneratedMarker+"\n")) {
+			stale = append(stale, filename)
+		}
+	}
+
+	return stale, nil
 }
 
 // CreateCommand creates cobra command
@@ -252,7 +728,8 @@ func CreateCommand(name, description string, generator Gen) *cobra.Command {
 
 			if err := generator.Generate(); err != nil {
 				log.Printf("generate error: %s", err)
-				return
+				// non-zero code fails CI builds using check mode
+				os.Exit(1)
 			}
 		},
 		FParseErrWhitelist: cobra.FParseErrWhitelist{
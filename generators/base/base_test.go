@@ -0,0 +1,147 @@
+package base
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func TestGenerator_GenerateFromEntities_Check(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genna_check")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	entities := []model.Entity{
+		model.NewEntity("public", "users", nil, nil),
+	}
+
+	tmpl := `package model
+
+const Table = {{range .}}{{quote .PGName}}{{end}}
+`
+	packer := func(entities []model.Entity) (interface{}, error) {
+		return entities, nil
+	}
+
+	output := path.Join(dir, "model.go")
+	checker := Generator{Check: true}
+
+	t.Run("Should report missing file", func(t *testing.T) {
+		if err := checker.GenerateFromEntities(entities, output, tmpl, packer); !errors.Is(err, ErrDrift) {
+			t.Errorf("GenerateFromEntities() error = %v, want %v", err, ErrDrift)
+		}
+
+		if _, err := os.Stat(output); !os.IsNotExist(err) {
+			t.Errorf("file should not be written in check mode")
+		}
+	})
+
+	t.Run("Should pass on generated file", func(t *testing.T) {
+		if err := (Generator{}).GenerateFromEntities(entities, output, tmpl, packer); err != nil {
+			t.Errorf("GenerateFromEntities() error = %v", err)
+			return
+		}
+
+		if err := checker.GenerateFromEntities(entities, output, tmpl, packer); err != nil {
+			t.Errorf("GenerateFromEntities() error = %v", err)
+		}
+	})
+
+	t.Run("Should report changed file", func(t *testing.T) {
+		if err := ioutil.WriteFile(output, []byte("package model\n"), 0644); err != nil {
+			t.Errorf("writing file error = %v", err)
+			return
+		}
+
+		if err := checker.GenerateFromEntities(entities, output, tmpl, packer); !errors.Is(err, ErrDrift) {
+			t.Errorf("GenerateFromEntities() error = %v, want %v", err, ErrDrift)
+		}
+	})
+}
+
+func TestGenerator_GenerateMultiFromEntities_Check(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genna_check_multi")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	tmpl := MultiTemplate{
+		Name:   "model",
+		Shared: "package model\n",
+		Entity: "package model\n\ntype {{range .}}{{.GoName}}{{end}} struct{}\n",
+	}
+	packer := func(entities []model.Entity) (interface{}, error) {
+		return entities, nil
+	}
+
+	entities := []model.Entity{
+		model.NewEntity("public", "users", nil, nil),
+		model.NewEntity("public", "projects", nil, nil),
+	}
+
+	if err := (Generator{}).GenerateMultiFromEntities(entities, dir, tmpl, packer); err != nil {
+		t.Errorf("GenerateMultiFromEntities() error = %v", err)
+		return
+	}
+
+	checker := Generator{Check: true}
+
+	t.Run("Should pass on generated files", func(t *testing.T) {
+		if err := checker.GenerateMultiFromEntities(entities, dir, tmpl, packer); err != nil {
+			t.Errorf("GenerateMultiFromEntities() error = %v", err)
+		}
+	})
+
+	t.Run("Should report stale files without removing", func(t *testing.T) {
+		if err := checker.GenerateMultiFromEntities(entities[:1], dir, tmpl, packer); !errors.Is(err, ErrDrift) {
+			t.Errorf("GenerateMultiFromEntities() error = %v, want %v", err, ErrDrift)
+		}
+
+		if _, err := os.Stat(path.Join(dir, "project_model.go")); err != nil {
+			t.Errorf("stale file should not be removed in check mode = %v", err)
+		}
+	})
+}
+
+func TestAddDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		custom  []string
+		decimal string
+		want    string
+		wantErr bool
+	}{
+		{name: "Should keep float by default", decimal: DecimalFloat, want: ""},
+		{name: "Should add shopspring decimal", decimal: DecimalShopspring, want: model.TypeDecimal},
+		{name: "Should add string", decimal: DecimalString, want: model.TypeString},
+		{name: "Should keep custom type", custom: []string{"numeric:github.com/ericlagergren/decimal.Big"}, decimal: DecimalShopspring, want: "decimal.Big"},
+		{name: "Should fail on unknown type", decimal: "big", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			customTypes, err := model.ParseCustomTypes(tt.custom)
+			if err != nil {
+				t.Errorf("ParseCustomTypes() error = %v", err)
+				return
+			}
+
+			if err := AddDecimal(customTypes, tt.decimal); (err != nil) != tt.wantErr {
+				t.Errorf("AddDecimal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got, _ := customTypes.GoType(model.TypePGNumeric); got != tt.want {
+				t.Errorf("AddDecimal() type = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
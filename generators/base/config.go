@@ -0,0 +1,338 @@
+package base
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfig is a config file read from working directory if config flag is not set
+const DefaultConfig = "genna.yaml"
+
+// Config is a yaml config file shared by all generators
+type Config struct {
+	// Flags are flag values by flag name, e.g. output: model/model.go
+	Flags map[string]interface{} `yaml:",inline"`
+
+	// Generators are flag values for one generator by command name, they override common values
+	Generators map[string]map[string]interface{} `yaml:"generators"`
+
+	// Overrides are per-table overrides by table name, e.g. users or geo.countries
+	Overrides Overrides `yaml:"overrides"`
+}
+
+// Overrides stores per-table overrides by table name
+type Overrides map[string]TableOverride
+
+// TableOverride stores overrides of one table
+type TableOverride struct {
+	// Name is a go name of model
+	Name string `yaml:"name"`
+	// Skip excludes table from generation
+	Skip bool `yaml:"skip"`
+	// SoftDelete is a soft delete column used instead of soft-delete flag
+	SoftDelete string `yaml:"soft-delete"`
+
+	Columns map[string]ColumnOverride `yaml:"columns"`
+}
+
+// ColumnOverride stores overrides of one column
+type ColumnOverride struct {
+	// Name is a go name of field
+	Name string `yaml:"name"`
+	// Type is a go type of field with import path if needed, e.g. github.com/google/uuid.UUID
+	Type string `yaml:"type"`
+	// JSON is a go type for json column used instead of json flag
+	JSON string `yaml:"json"`
+	// Tags are additional struct tags by tag name
+	Tags map[string]string `yaml:"tags"`
+	// Skip excludes column from generation
+	Skip bool `yaml:"skip"`
+}
+
+// ReadConfig reads config from yaml file
+func ReadConfig(filename string) (Config, error) {
+	var config Config
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return config, err
+	}
+
+	// unknown fields of overrides are errors, unknown top level keys are flags of other generators
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return config, fmt.Errorf("parsing config error: %w", err)
+	}
+
+	return config, nil
+}
+
+// ApplyConfig reads config file set by config flag or default one if exists
+// and sets values of flags which are not set in command line
+func ApplyConfig(command *cobra.Command) (Overrides, error) {
+	flags := command.Flags()
+
+	filename, err := flags.GetString(ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if filename == "" {
+		if _, err := os.Stat(DefaultConfig); err != nil {
+			return nil, nil
+		}
+		filename = DefaultConfig
+	}
+
+	config, err := ReadConfig(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s error: %w", filename, err)
+	}
+
+	values := map[string]interface{}{}
+	for name, value := range config.Flags {
+		values[name] = value
+	}
+	for name, value := range config.Generators[command.Name()] {
+		values[name] = value
+	}
+
+	for name, value := range values {
+		flag := flags.Lookup(name)
+		// config is shared by generators with different flags
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		if err := setFlag(flag, value); err != nil {
+			return nil, fmt.Errorf("config value of %s error: %w", name, err)
+		}
+	}
+
+	return config.Overrides, nil
+}
+
+// setFlag sets flag value from yaml value, lists are used for slices and maps for key=value flags
+func setFlag(flag *pflag.Flag, value interface{}) error {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, value := range v {
+			values[i] = fmt.Sprint(value)
+		}
+
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			return slice.Replace(values)
+		}
+		return flag.Value.Set(strings.Join(values, ","))
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if err := flag.Value.Set(fmt.Sprintf("%s=%v", key, v[key])); err != nil {
+				return err
+			}
+		}
+		return nil
+	case nil:
+		return nil
+	}
+
+	return flag.Value.Set(fmt.Sprint(value))
+}
+
+// Table gets overrides of table
+func (o Overrides) Table(schema, table string) TableOverride {
+	if override, ok := o[util.Join(schema, table)]; ok {
+		return override
+	}
+
+	return o[util.JoinF(schema, table)]
+}
+
+// Column gets overrides of column
+func (o Overrides) Column(schema, table, column string) ColumnOverride {
+	return o.Table(schema, table).Columns[column]
+}
+
+// FieldName gets go name of column field, primary key is named ID unless keepPK is set or name is set in config
+func (o Overrides) FieldName(entity model.Entity, column model.Column, keepPK bool) string {
+	if !column.IsPK || keepPK || o.Column(entity.PGSchema, entity.PGName, column.PGName).Name != "" {
+		return column.GoName
+	}
+
+	return util.ID
+}
+
+// Check reports overrides of unknown columns of read tables, so typos in config are not ignored
+// overrides of tables which are not read are skipped, as config is shared by runs reading different tables
+func (o Overrides) Check(entities []model.Entity) error {
+	tables := map[string]model.Entity{}
+	for _, entity := range entities {
+		tables[util.Join(entity.PGSchema, entity.PGName)] = entity
+		tables[util.JoinF(entity.PGSchema, entity.PGName)] = entity
+	}
+
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entity, ok := tables[name]
+		if !ok {
+			continue
+		}
+
+		override := o[name]
+		columns := make([]string, 0, len(override.Columns)+1)
+		for column := range override.Columns {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		if override.SoftDelete != "" {
+			columns = append(columns, override.SoftDelete)
+		}
+
+		for _, column := range columns {
+			if !hasColumn(entity, column) {
+				return fmt.Errorf("overrides of unknown column %s of table %s", column, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply applies names, types and skips to entities
+// relations to skipped tables and by skipped columns are removed
+func (o Overrides) Apply(entities []model.Entity) ([]model.Entity, error) {
+	if len(o) == 0 {
+		return entities, nil
+	}
+
+	var result []model.Entity
+	for _, entity := range entities {
+		override := o.Table(entity.PGSchema, entity.PGName)
+		if override.Skip {
+			continue
+		}
+
+		if override.Name != "" {
+			entity.GoName = override.Name
+			entity.GoNamePlural = util.Plural(override.Name)
+		}
+
+		skipped := util.NewSet()
+		imports := util.NewSet()
+
+		var columns []model.Column
+		for _, column := range entity.Columns {
+			co := override.Columns[column.PGName]
+			if co.Skip {
+				skipped.Add(column.PGName)
+				continue
+			}
+
+			if co.Name != "" {
+				column.GoName = co.Name
+			}
+
+			if co.Type != "" {
+				// pointers and slices of imported types are allowed
+				typ := strings.TrimLeft(co.Type, "*[]")
+				goType, goImport, err := model.ParseGoType(typ)
+				if err != nil {
+					return nil, fmt.Errorf("type of %s.%s error: %w", entity.PGFullName, column.PGName, err)
+				}
+
+				column.Type = co.Type[:len(co.Type)-len(typ)] + goType
+				column.Import = goImport
+			}
+
+			if column.Import != "" {
+				imports.Add(column.Import)
+			}
+
+			columns = append(columns, column)
+		}
+
+		var relations []model.Relation
+		for _, relation := range entity.Relations {
+			target := o.Table(relation.TargetPGSchema, relation.TargetPGName)
+			if target.Skip || relation.Type == model.HasOne && hasAny(skipped, relation.FKFields) {
+				continue
+			}
+
+			if target.Name != "" {
+				// keeping package of target type in package-per-schema output
+				qualifier := relation.GoType[:strings.LastIndex(relation.GoType, ".")+1]
+				relation.GoType = qualifier + target.Name
+			}
+
+			if relation.Through != nil {
+				through := o.Table(relation.Through.PGSchema, relation.Through.PGName)
+				if through.Skip {
+					continue
+				}
+
+				if through.Name != "" {
+					junction := *relation.Through
+					junction.GoName = through.Name
+					relation.Through = &junction
+				}
+			}
+
+			relations = append(relations, relation)
+		}
+
+		entity.Columns = columns
+		entity.Relations = relations
+		entity.Imports = imports.Elements()
+
+		result = append(result, entity)
+	}
+
+	return result, nil
+}
+
+// hasColumn checks if entity has column with pg name
+func hasColumn(entity model.Entity, name string) bool {
+	for _, column := range entity.Columns {
+		if column.PGName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasAny checks if set contains any of elements
+func hasAny(set util.Set, elements []string) bool {
+	for _, element := range elements {
+		if set.Exists(element) {
+			return true
+		}
+	}
+
+	return false
+}
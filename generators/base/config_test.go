@@ -0,0 +1,217 @@
+package base
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genna_config")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	config := `
+conn: postgres://localhost/db
+output: model.go
+tables: [public.*, geo.*]
+follow-fk: true
+unknown: value
+generators:
+  model:
+    output: model/model.go
+overrides:
+  users:
+    name: Account
+`
+	filename := path.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(filename, []byte(config), 0644); err != nil {
+		t.Errorf("writing config error = %v", err)
+		return
+	}
+
+	command := &cobra.Command{Use: "model"}
+	AddFlags(command)
+	if err := command.ParseFlags([]string{"--config", filename, "-c", "postgres://localhost/other"}); err != nil {
+		t.Errorf("parsing flags error = %v", err)
+		return
+	}
+
+	options, _, err := ReadFlags(command)
+	if err != nil {
+		t.Errorf("ReadFlags() error = %v", err)
+		return
+	}
+
+	if options.URL != "postgres://localhost/other" {
+		t.Errorf("flag should override config, URL = %v", options.URL)
+	}
+
+	if options.Output != "model/model.go" {
+		t.Errorf("generator config should override common one, Output = %v", options.Output)
+	}
+
+	if want := []string{"public.*", "geo.*"}; !reflect.DeepEqual(options.Tables, want) {
+		t.Errorf("Tables = %v, want %v", options.Tables, want)
+	}
+
+	if !options.FollowFKs {
+		t.Errorf("FollowFKs should be set from config")
+	}
+
+	if options.Overrides.Table("public", "users").Name != "Account" {
+		t.Errorf("Overrides = %v", options.Overrides)
+	}
+}
+
+func TestOverrides_Apply(t *testing.T) {
+	countries := model.NewEntity("geo", "countries", []model.Column{
+		model.NewColumn("countryId", model.TypePGInt4, "", false, false, false, false, 0, true, false, 0, nil, 10, nil),
+	}, nil)
+
+	users := model.NewEntity("public", "users", []model.Column{
+		model.NewColumn("userId", model.TypePGInt4, "", false, false, false, false, 0, true, false, 0, nil, 10, nil),
+		model.NewColumn("countryId", model.TypePGInt4, "", false, true, false, false, 0, false, true, 0, nil, 10, nil),
+		model.NewColumn("token", model.TypePGText, "", false, true, false, false, 0, false, false, 0, nil, 10, nil),
+		model.NewColumn("loggedAt", model.TypePGTimestamptz, "", false, true, false, false, 0, false, false, 0, nil, 10, nil),
+	}, []model.Relation{
+		model.NewRelation([]string{"countryId"}, "geo", "countries"),
+	})
+
+	overrides := Overrides{
+		"users": {
+			Name: "Account",
+			Columns: map[string]ColumnOverride{
+				"token":    {Name: "APIToken", Type: "*github.com/google/uuid.UUID"},
+				"loggedAt": {Skip: true},
+			},
+		},
+		"geo.countries": {Name: "Country"},
+	}
+
+	entities, err := overrides.Apply([]model.Entity{users, countries})
+	if err != nil {
+		t.Errorf("Apply() error = %v", err)
+		return
+	}
+
+	account := entities[0]
+	if account.GoName != "Account" || account.GoNamePlural != "Accounts" {
+		t.Errorf("entity names = %v, %v", account.GoName, account.GoNamePlural)
+	}
+
+	if len(account.Columns) != 3 {
+		t.Errorf("skipped column should be removed, columns = %v", len(account.Columns))
+		return
+	}
+
+	token := account.Columns[2]
+	if token.GoName != "APIToken" || token.Type != "*uuid.UUID" {
+		t.Errorf("column = %v %v", token.GoName, token.Type)
+	}
+
+	if want := []string{"github.com/google/uuid"}; !reflect.DeepEqual(account.Imports, want) {
+		t.Errorf("Imports = %v, want %v", account.Imports, want)
+	}
+
+	if len(account.Relations) != 1 || account.Relations[0].GoType != "Country" {
+		t.Errorf("relation type should be renamed, relations = %v", account.Relations)
+	}
+
+	t.Run("Should skip relations to skipped tables", func(t *testing.T) {
+		overrides := Overrides{"geo.countries": {Skip: true}}
+
+		entities, err := overrides.Apply([]model.Entity{users, countries})
+		if err != nil {
+			t.Errorf("Apply() error = %v", err)
+			return
+		}
+
+		if len(entities) != 1 || len(entities[0].Relations) != 0 {
+			t.Errorf("Apply() = %v", entities)
+		}
+	})
+}
+
+func TestReadConfig_UnknownFields(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genna_config")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	config := `
+overrides:
+  users:
+    columns:
+      token:
+        nmae: APIToken
+`
+	filename := path.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(filename, []byte(config), 0644); err != nil {
+		t.Errorf("writing config error = %v", err)
+		return
+	}
+
+	if _, err := ReadConfig(filename); err == nil {
+		t.Errorf("ReadConfig() error = nil, want error")
+	}
+}
+
+func TestOverrides_Check(t *testing.T) {
+	entities := []model.Entity{
+		model.NewEntity("public", "users", []model.Column{
+			model.NewColumn("userId", model.TypePGInt4, "", false, false, false, false, 0, true, false, 0, nil, 10, nil),
+			model.NewColumn("deletedAt", model.TypePGTimestamptz, "", false, true, false, false, 0, false, false, 0, nil, 10, nil),
+		}, nil),
+		model.NewEntity("geo", "countries", nil, nil),
+	}
+
+	tests := []struct {
+		name      string
+		overrides Overrides
+		wantErr   bool
+	}{
+		{
+			name: "Should accept known tables and columns",
+			overrides: Overrides{
+				"users":         {SoftDelete: "deletedAt", Columns: map[string]ColumnOverride{"userId": {Name: "UserID"}}},
+				"geo.countries": {Name: "Country"},
+			},
+		},
+		{
+			name: "Should skip tables which are not read",
+			overrides: Overrides{
+				"orders":      {Name: "Purchase", Columns: map[string]ColumnOverride{"orderId": {Name: "OrderID"}}},
+				"geo.regions": {SoftDelete: "deletedAt"},
+			},
+		},
+		{
+			name:      "Should report unknown column",
+			overrides: Overrides{"public.users": {Columns: map[string]ColumnOverride{"userID": {Name: "UserID"}}}},
+			wantErr:   true,
+		},
+		{
+			name:      "Should report unknown soft delete column",
+			overrides: Overrides{"users": {SoftDelete: "deleted"}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.overrides.Check(entities); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
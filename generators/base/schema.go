@@ -0,0 +1,177 @@
+package base
+
+import (
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+// SchemaPackage is a go package with entities of one schema
+type SchemaPackage struct {
+	Schema     string
+	Name       string
+	ImportPath string
+
+	Entities []model.Entity
+}
+
+// SchemaPackages groups entities by schema for package-per-schema output
+// schema prefix is removed from type names and relations to other schemas use their packages,
+// relations which cause import cycles and has-many & many2many relations to other schemas are dropped
+func SchemaPackages(entities []model.Entity, importPath string) []SchemaPackage {
+	var packages []SchemaPackage
+	index := map[string]int{}
+	for _, entity := range entities {
+		if _, ok := index[entity.PGSchema]; !ok {
+			index[entity.PGSchema] = len(packages)
+			packages = append(packages, SchemaPackage{
+				Schema:     entity.PGSchema,
+				Name:       util.PackageName(entity.PGSchema),
+				ImportPath: importPath + "/" + util.PackageName(entity.PGSchema),
+			})
+		}
+	}
+
+	deps := schemaDependencies(entities)
+	for _, entity := range entities {
+		i := index[entity.PGSchema]
+		packages[i].Entities = append(packages[i].Entities, schemaEntity(entity, importPath, deps))
+	}
+
+	return packages
+}
+
+// PackageName gets package name for entities, it is schema package name for package-per-schema output
+func PackageName(options Options, pkg string, entities []model.Entity) string {
+	if options.SchemaPackage && len(entities) > 0 {
+		return util.PackageName(entities[0].PGSchema)
+	}
+
+	return pkg
+}
+
+// schemaDependencies gets schemas referenced by has-one relations of every schema
+func schemaDependencies(entities []model.Entity) map[string][]string {
+	deps := map[string][]string{}
+	for _, entity := range entities {
+		for _, relation := range entity.Relations {
+			if relation.Type == model.HasOne && relation.TargetPGSchema != entity.PGSchema {
+				deps[entity.PGSchema] = append(deps[entity.PGSchema], relation.TargetPGSchema)
+			}
+		}
+	}
+
+	return deps
+}
+
+// dependsOn checks if schema references target schema directly or through other schemas
+func dependsOn(deps map[string][]string, schema, target string, visited util.Set) bool {
+	if !visited.Add(schema) {
+		return false
+	}
+
+	for _, dep := range deps[schema] {
+		if dep == target || dependsOn(deps, dep, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schemaComposite gets copy of composite type with names of schema package, composite types of fields are copied too
+func schemaComposite(composite model.Composite, schema, prefix string) model.Composite {
+	if composite.PGSchema == schema {
+		composite.GoName = strings.TrimPrefix(composite.GoName, prefix)
+	}
+
+	fields := make([]model.Column, len(composite.Fields))
+	for i, field := range composite.Fields {
+		if field.Composite != nil {
+			nested := schemaComposite(*field.Composite, schema, prefix)
+			field.Composite = &nested
+		}
+		fields[i] = field
+	}
+	composite.Fields = fields
+
+	return composite
+}
+
+// schemaEntity gets copy of entity for its schema package
+func schemaEntity(entity model.Entity, importPath string, deps map[string][]string) model.Entity {
+	schema := entity.PGSchema
+
+	// public entities have no schema prefix
+	prefix := ""
+	if schema != util.PublicSchema {
+		prefix = util.CamelCased(schema)
+	}
+
+	entity.GoName = util.EntityName(entity.PGName)
+	entity.GoNamePlural = util.CamelCased(util.Sanitize(entity.PGName))
+
+	columns := make([]model.Column, len(entity.Columns))
+	for i, column := range entity.Columns {
+		if column.Enum != nil && column.Enum.PGSchema == schema {
+			enum := *column.Enum
+			enum.GoName = strings.TrimPrefix(enum.GoName, prefix)
+			column.Enum = &enum
+		}
+		if column.Domain != nil && column.Domain.PGSchema == schema {
+			domain := *column.Domain
+			domain.GoName = strings.TrimPrefix(domain.GoName, prefix)
+			column.Domain = &domain
+		}
+		if column.Composite != nil {
+			composite := schemaComposite(*column.Composite, schema, prefix)
+			column.Composite = &composite
+		}
+		columns[i] = column
+	}
+	entity.Columns = columns
+
+	imports := util.NewSet()
+	for _, imp := range entity.Imports {
+		imports.Add(imp)
+	}
+
+	var relations []model.Relation
+	for _, relation := range entity.Relations {
+		target := relation.TargetPGSchema
+		goType := util.EntityName(relation.TargetPGName)
+
+		if target != schema {
+			// inverse relations to other schemas would import each other
+			if relation.Type != model.HasOne || dependsOn(deps, target, schema, util.NewSet()) {
+				continue
+			}
+
+			imports.Add(importPath + "/" + util.PackageName(target))
+			goType = util.PackageName(target) + "." + goType
+		}
+
+		if relation.Type != model.HasOne {
+			plural := util.CamelCased(util.Sanitize(relation.TargetPGName))
+			relation.GoName = strings.Replace(relation.GoName, prefix+plural, plural, 1)
+		}
+
+		if relation.Through != nil {
+			if relation.Through.PGSchema != schema {
+				continue
+			}
+
+			through := *relation.Through
+			through.GoName = util.EntityName(through.PGName)
+			relation.Through = &through
+		}
+
+		relation.GoType = goType
+		relations = append(relations, relation)
+	}
+	entity.Relations = relations
+	entity.Imports = imports.Elements()
+
+	return entity
+}
@@ -0,0 +1,126 @@
+package base
+
+import (
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/dizzyfool/genna/lib"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+func TestSchemaPackages(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	generator := genna.NewFromDDL(path.Join(path.Dir(filename), "..", "..", "test_db.sql"), nil)
+
+	entities, err := generator.Read([]string{"public.*", "geo.*", "shop.*"}, false, false, false, 10, nil)
+	if err != nil {
+		t.Errorf("Genna.Read error %v", err)
+		return
+	}
+
+	packages := SchemaPackages(entities, "github.com/user/project/model")
+
+	index := map[string]model.Entity{}
+	for _, pkg := range packages {
+		for _, entity := range pkg.Entities {
+			if PackageName(Options{SchemaPackage: true}, "", pkg.Entities) != pkg.Name {
+				t.Errorf("package name = %v, want %v", PackageName(Options{SchemaPackage: true}, "", pkg.Entities), pkg.Name)
+			}
+			index[pkg.Name+"."+entity.GoName] = entity
+		}
+	}
+
+	if ln := len(packages); ln != 3 {
+		t.Errorf("len(packages) = %v, want %v", ln, 3)
+		return
+	}
+
+	t.Run("Should remove schema prefix", func(t *testing.T) {
+		for _, name := range []string{"public.User", "geo.Country", "shop.Order", "shop.Item"} {
+			if _, ok := index[name]; !ok {
+				t.Errorf("entity %s not found", name)
+			}
+		}
+	})
+
+	t.Run("Should use package of other schema", func(t *testing.T) {
+		user := index["public.User"]
+		if user.Relations[0].GoType != "geo.Country" {
+			t.Errorf("Relation.GoType = %v, want %v", user.Relations[0].GoType, "geo.Country")
+		}
+
+		found := false
+		for _, imp := range user.Imports {
+			if imp == "github.com/user/project/model/geo" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Entity.Imports = %v, want geo package", user.Imports)
+		}
+	})
+
+	t.Run("Should drop inverse relations to other schemas", func(t *testing.T) {
+		if ln := len(index["geo.Country"].Relations); ln != 0 {
+			t.Errorf("len(Relations) = %v, want %v", ln, 0)
+		}
+
+		item := index["shop.Item"]
+		names := map[string]string{}
+		for _, relation := range item.Relations {
+			names[relation.GoName] = relation.GoType
+		}
+		if names["Orders"] != "Order" || names["Tags"] != "Tag" {
+			t.Errorf("relations = %v, want Orders & Tags", names)
+		}
+	})
+}
+
+func Test_dependsOn(t *testing.T) {
+	deps := map[string][]string{
+		"public": {"geo"},
+		"geo":    {"dict"},
+		"dict":   {"geo"},
+	}
+
+	tests := []struct {
+		name   string
+		schema string
+		target string
+		want   bool
+	}{
+		{
+			name:   "Should depend directly",
+			schema: "public",
+			target: "geo",
+			want:   true,
+		},
+		{
+			name:   "Should depend through other schema",
+			schema: "public",
+			target: "dict",
+			want:   true,
+		},
+		{
+			name:   "Should not depend on referencing schema",
+			schema: "geo",
+			target: "public",
+			want:   false,
+		},
+		{
+			name:   "Should detect cycle",
+			schema: "dict",
+			target: "dict",
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependsOn(deps, tt.schema, tt.target, util.NewSet()); got != tt.want {
+				t.Errorf("dependsOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
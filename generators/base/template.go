@@ -0,0 +1,102 @@
+package base
+
+import (
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"unicode"
+
+	"github.com/dizzyfool/genna/util"
+)
+
+const (
+	// SharedTemplateFile is a name of shared file template in templates directory for multi-file output
+	SharedTemplateFile = "shared.tmpl"
+
+	// EntityTemplateFile is a name of entity file template in templates directory for multi-file output
+	EntityTemplateFile = "entity.tmpl"
+)
+
+// TemplateFuncs are functions available in every template
+//
+//	camel       user_name -> UserName
+//	underscore  UserName -> user_name
+//	lowerFirst  UserName -> userName
+//	entityName  users -> User
+//	columnName  user_id -> UserID
+//	singular    users -> user
+//	plural      user -> users
+//	quote       user -> "user"
+//	join        joins slice of strings with separator
+//	doc         table comment -> // table comment
+var TemplateFuncs = texttemplate.FuncMap{
+	"camel":      util.CamelCased,
+	"underscore": util.Underscore,
+	"lowerFirst": util.LowerFirst,
+	"entityName": util.EntityName,
+	"columnName": util.ColumnName,
+	"singular":   util.Singular,
+	"plural":     util.Plural,
+	"quote":      func(s string) template.HTML { return template.HTML(strconv.Quote(s)) },
+	"join":       func(elems []string, sep string) template.HTML { return template.HTML(strings.Join(elems, sep)) },
+	"doc":        DocComment,
+}
+
+// DocComment gets go doc comment from table or column comment, every line ends with new line
+// empty string is returned for empty comment
+func DocComment(comment string) template.HTML {
+	comment = strings.TrimSpace(strings.ReplaceAll(comment, "\r", ""))
+	if comment == "" {
+		return ""
+	}
+
+	var builder strings.Builder
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+		if line == "" {
+			builder.WriteString("//\n")
+			continue
+		}
+		builder.WriteString("// " + line + "\n")
+	}
+
+	return template.HTML(builder.String())
+}
+
+// ReadTemplate reads user template from file
+func ReadTemplate(filename string) (string, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("reading template file error: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// ReadMultiTemplate reads user templates for multi-file output from directory
+// directory should contain shared.tmpl and entity.tmpl files
+func ReadMultiTemplate(dir string, tmpl MultiTemplate) (MultiTemplate, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return tmpl, fmt.Errorf("reading templates directory error: %w", err)
+	}
+
+	if !info.IsDir() {
+		return tmpl, fmt.Errorf("templates for multi-file output should be a directory with %s and %s", SharedTemplateFile, EntityTemplateFile)
+	}
+
+	if tmpl.Shared, err = ReadTemplate(path.Join(dir, SharedTemplateFile)); err != nil {
+		return tmpl, err
+	}
+
+	if tmpl.Entity, err = ReadTemplate(path.Join(dir, EntityTemplateFile)); err != nil {
+		return tmpl, err
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,151 @@
+package base
+
+import (
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func TestGenerator_GenerateFromEntities_Template(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genna_template")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	entities := []model.Entity{
+		model.NewEntity("public", "user_roles", nil, nil),
+	}
+
+	tmpl := `package model
+
+const Table = {{range .}}{{quote .PGName}}{{end}}
+
+// {{range .}}{{camel .PGName}} {{underscore .GoName}} {{plural .GoName}} {{singular .PGName}} {{lowerFirst .GoName}}{{end}}
+`
+	packer := func(entities []model.Entity) (interface{}, error) {
+		return entities, nil
+	}
+
+	output := path.Join(dir, "model.go")
+	if err := (Generator{}).GenerateFromEntities(entities, output, tmpl, packer); err != nil {
+		t.Errorf("GenerateFromEntities() error = %v", err)
+		return
+	}
+
+	generated, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Errorf("file not generated = %v", err)
+		return
+	}
+
+	want := `package model
+
+const Table = "user_roles"
+
+// UserRoles user_role UserRoles user_role userRole
+`
+	if string(generated) != want {
+		t.Errorf("generated = %v, want %v", string(generated), want)
+	}
+}
+
+func TestGenerator_GenerateFromEntities_TemplateNotEscaped(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genna_template")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	entity := model.NewEntity("public", "users", nil, nil)
+	entity.Comment = `"a" < 'b' & c`
+
+	tmpl := `package model
+
+// {{range .}}{{.Comment}}{{end}}
+var ok = len("{{range .}}{{.PGName}}{{end}}") < 10 && true
+`
+	packer := func(entities []model.Entity) (interface{}, error) {
+		return entities, nil
+	}
+
+	output := path.Join(dir, "model.go")
+	if err := (Generator{}).GenerateFromEntities([]model.Entity{entity}, output, tmpl, packer); err != nil {
+		t.Errorf("GenerateFromEntities() error = %v", err)
+		return
+	}
+
+	generated, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Errorf("file not generated = %v", err)
+		return
+	}
+
+	want := `package model
+
+// "a" < 'b' & c
+var ok = len("users") < 10 && true
+`
+	if string(generated) != want {
+		t.Errorf("generated = %v, want %v", string(generated), want)
+	}
+}
+
+func TestReadMultiTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genna_templates")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{SharedTemplateFile, EntityTemplateFile} {
+		if err := ioutil.WriteFile(path.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Errorf("writing template error = %v", err)
+			return
+		}
+	}
+
+	t.Run("Should read templates from directory", func(t *testing.T) {
+		tmpl, err := ReadMultiTemplate(dir, MultiTemplate{Name: "model"})
+		if err != nil {
+			t.Errorf("ReadMultiTemplate() error = %v", err)
+			return
+		}
+
+		if tmpl.Name != "model" || tmpl.Shared != SharedTemplateFile || tmpl.Entity != EntityTemplateFile {
+			t.Errorf("ReadMultiTemplate() = %+v", tmpl)
+		}
+	})
+
+	t.Run("Should fail on file", func(t *testing.T) {
+		if _, err := ReadMultiTemplate(path.Join(dir, SharedTemplateFile), MultiTemplate{}); err == nil {
+			t.Errorf("ReadMultiTemplate() error = nil, want error")
+		}
+	})
+}
+
+func TestDocComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    template.HTML
+	}{
+		{name: "Should get empty comment", comment: " \n", want: ""},
+		{name: "Should get single line comment", comment: "Registered users", want: "// Registered users\n"},
+		{name: "Should get multi line comment", comment: "Registered users\r\n\r\n  * admins\n", want: "// Registered users\n//\n//   * admins\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DocComment(tt.comment); got != tt.want {
+				t.Errorf("DocComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
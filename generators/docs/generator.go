@@ -0,0 +1,130 @@
+package docs
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("docs", "Markdown data dictionary with ER diagram generator", New())
+}
+
+// Docs represents docs generator
+type Docs struct {
+	options Options
+}
+
+// New creates generator
+func New() *Docs {
+	return &Docs{}
+}
+
+// Options gets options
+func (g *Docs) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *Docs) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *Docs) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+}
+
+// ReadFlags read flags from command
+func (g *Docs) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if g.options.Options, _, err = base.ReadFlags(command); err != nil {
+		return err
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+// output is a directory, every schema is written to its own <schema>.md file
+func (g *Docs) Generate() error {
+	if g.options.MultiFile || g.options.SchemaPackage {
+		return fmt.Errorf("multi-file and schema package output are not supported by docs generator, every schema is written to its own file")
+	}
+
+	tmpl := Template
+	if g.options.Template != "" {
+		custom, err := base.ReadTemplate(g.options.Template)
+		if err != nil {
+			return err
+		}
+		tmpl = custom
+	}
+
+	generator := base.NewGeneratorFromOptions(g.options.Options)
+
+	entities, err := generator.Read(g.options.Tables, g.options.Views, g.options.FollowFKs, false, g.options.GoPgVer, g.options.CustomTypes)
+	if err != nil {
+		return fmt.Errorf("read database error: %w", err)
+	}
+
+	drift := false
+	for _, schema := range Schemas(entities) {
+		filename := path.Join(g.options.Output, schema.Name+".md")
+		if err := generator.GenerateFromEntities(schema.Entities, filename, tmpl, g.Packer()); err != nil {
+			// checking every schema before reporting drift
+			if !errors.Is(err, base.ErrDrift) {
+				return err
+			}
+			drift = true
+		}
+	}
+
+	if drift {
+		return base.ErrDrift
+	}
+
+	return nil
+}
+
+// Packer returns packer function for compile entities into package
+func (g *Docs) Packer() base.Packer {
+	return func(entities []model.Entity) (interface{}, error) {
+		return NewTemplatePackage(entities), nil
+	}
+}
+
+// Schema stores entities of schema
+type Schema struct {
+	Name     string
+	Entities []model.Entity
+}
+
+// Schemas groups entities by schema keeping order of entities
+func Schemas(entities []model.Entity) []Schema {
+	var schemas []Schema
+	index := map[string]int{}
+
+	for _, entity := range entities {
+		i, ok := index[entity.PGSchema]
+		if !ok {
+			i = len(schemas)
+			index[entity.PGSchema] = i
+			schemas = append(schemas, Schema{Name: entity.PGSchema})
+		}
+
+		schemas[i].Entities = append(schemas[i].Entities, entity)
+	}
+
+	return schemas
+}
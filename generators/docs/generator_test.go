@@ -0,0 +1,46 @@
+package docs
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"testing"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+
+	generator := New()
+
+	generator.options.Def()
+	generator.options.DDL = path.Join(path.Dir(filename), "..", "..", "test_db.sql")
+	generator.options.Output = path.Join(os.TempDir(), "docs_test")
+	generator.options.Tables = []string{"public.*", "geo.*", "shop.*"}
+
+	if err := generator.Generate(); err != nil {
+		t.Errorf("generate error = %v", err)
+		return
+	}
+
+	for _, name := range []string{"public.md", "geo.md"} {
+		if _, err := os.Stat(path.Join(generator.options.Output, name)); err != nil {
+			t.Errorf("file %s not generated = %v", name, err)
+		}
+	}
+
+	generated, err := ioutil.ReadFile(path.Join(generator.options.Output, "shop.md"))
+	if err != nil {
+		t.Errorf("file not generated = %v", err)
+	}
+
+	check, err := ioutil.ReadFile(path.Join(path.Dir(filename), "generator_test.output"))
+	if err != nil {
+		t.Errorf("check file not found = %v", err)
+	}
+
+	if string(generated) != string(check) {
+		t.Errorf("generated does not match with check")
+		return
+	}
+}
@@ -0,0 +1,261 @@
+package docs
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+var (
+	mermaidNameRe    = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+	mermaidTypeRe    = regexp.MustCompile(`[^A-Za-z0-9_\-\[\]()]`)
+	markdownEscaping = strings.NewReplacer("|", `\|`, "\r", "", "\n", " ", "`", "'")
+)
+
+// TemplatePackage stores schema document info
+type TemplatePackage struct {
+	Schema string
+
+	Entities []TemplateEntity
+
+	// Relations are lines of mermaid diagram
+	Relations []template.HTML
+}
+
+// NewTemplatePackage creates a schema document for template
+// entities should be from the same schema
+func NewTemplatePackage(entities []model.Entity) TemplatePackage {
+	schema := ""
+	if len(entities) > 0 {
+		schema = entities[0].PGSchema
+	}
+
+	models := make([]TemplateEntity, len(entities))
+	var relations []template.HTML
+	for i, entity := range entities {
+		models[i] = NewTemplateEntity(entity, schema)
+
+		for _, relation := range entity.Relations {
+			if relation.Type == model.HasOne {
+				relations = append(relations, diagramRelation(entity, relation))
+			}
+		}
+	}
+
+	return TemplatePackage{
+		Schema: schema,
+
+		Entities:  models,
+		Relations: relations,
+	}
+}
+
+// TemplateEntity stores table info
+type TemplateEntity struct {
+	model.Entity
+
+	// DiagramName is entity name in mermaid diagram
+	DiagramName string
+	// Anchor is link to table section
+	Anchor string
+
+	IsView bool
+
+	// Description is a table comment
+	Description template.HTML
+
+	Columns []TemplateColumn
+}
+
+// NewTemplateEntity creates a table for template
+func NewTemplateEntity(entity model.Entity, schema string) TemplateEntity {
+	references := map[string]template.HTML{}
+	for _, relation := range entity.Relations {
+		if relation.Type != model.HasOne {
+			continue
+		}
+
+		link := template.HTML(fmt.Sprintf("[%s](%s)", relation.TargetPGFullName, tableLink(relation.TargetPGSchema, relation.TargetPGName, schema)))
+		for i, fk := range relation.FKFields {
+			if i < len(relation.PKFields) {
+				references[fk] = template.HTML(fmt.Sprintf("%s.%s", link, escape(relation.PKFields[i])))
+				continue
+			}
+			references[fk] = link
+		}
+	}
+
+	columns := make([]TemplateColumn, len(entity.Columns))
+	for i, column := range entity.Columns {
+		columns[i] = NewTemplateColumn(column, references[column.PGName])
+	}
+
+	return TemplateEntity{
+		Entity: entity,
+
+		DiagramName: diagramName(entity.PGSchema, entity.PGName),
+		Anchor:      anchor(entity.PGName),
+
+		IsView: entity.IsView(),
+
+		Description: template.HTML(strings.TrimSpace(strings.ReplaceAll(entity.Comment, "\r", ""))),
+
+		Columns: columns,
+	}
+}
+
+// TemplateColumn stores column info
+type TemplateColumn struct {
+	model.Column
+
+	Name       template.HTML
+	Type       template.HTML
+	IsNullable string
+	Default    template.HTML
+	Key        template.HTML
+	Values     template.HTML
+	Comment    template.HTML
+
+	// DiagramType and DiagramName are used in entity of mermaid diagram
+	DiagramType template.HTML
+	DiagramName template.HTML
+	DiagramKey  string
+}
+
+// NewTemplateColumn creates a column for template, reference is link to column referenced by foreign key
+func NewTemplateColumn(column model.Column, reference template.HTML) TemplateColumn {
+	typ := pgType(column)
+
+	nullable := "no"
+	if column.Nullable {
+		nullable = "yes"
+	}
+
+	def := template.HTML("")
+	if column.HasDefault && column.Default != "" {
+		def = template.HTML("`" + escape(column.Default) + "`")
+	}
+
+	var keys, diagramKeys []string
+	if column.IsPK {
+		keys = append(keys, "PK")
+		diagramKeys = append(diagramKeys, "PK")
+	}
+	if column.IsFK {
+		key := "FK"
+		if reference != "" {
+			key += " " + string(reference)
+		}
+		keys = append(keys, key)
+		diagramKeys = append(diagramKeys, "FK")
+	}
+
+	values := make([]string, len(column.Values))
+	for i, value := range column.Values {
+		values[i] = "`" + escape(value) + "`"
+	}
+
+	return TemplateColumn{
+		Column: column,
+
+		Name:       template.HTML(escape(column.PGName)),
+		Type:       template.HTML(escape(typ)),
+		IsNullable: nullable,
+		Default:    def,
+		Key:        template.HTML(strings.Join(keys, ", ")),
+		Values:     template.HTML(strings.Join(values, ", ")),
+		Comment:    template.HTML(escape(strings.TrimSpace(column.Comment))),
+
+		DiagramType: template.HTML(mermaidTypeRe.ReplaceAllString(typ, "_")),
+		DiagramName: template.HTML(mermaidNameRe.ReplaceAllString(column.PGName, "_")),
+		DiagramKey:  strings.Join(diagramKeys, ", "),
+	}
+}
+
+// pgType gets type of column as it is declared
+func pgType(column model.Column) string {
+	typ := column.PGType
+	if column.Enum != nil {
+		typ = column.Enum.PGFullName
+	} else if column.MaxLen > 0 {
+		typ = fmt.Sprintf("%s(%d)", typ, column.MaxLen)
+	} else if column.Precision > 0 {
+		typ = fmt.Sprintf("%s(%d,%d)", typ, column.Precision, column.Scale)
+	}
+
+	if column.IsArray {
+		dims := column.Dimensions
+		if dims == 0 {
+			dims = 1
+		}
+		typ += strings.Repeat("[]", dims)
+	}
+
+	return typ
+}
+
+// diagramRelation gets mermaid relation line from entity with foreign key to referenced one
+func diagramRelation(entity model.Entity, relation model.Relation) template.HTML {
+	// foreign key is optional if any of its columns is nullable
+	fks := util.NewSet()
+	for _, fk := range relation.FKFields {
+		fks.Add(fk)
+	}
+
+	target := "||"
+	for _, column := range entity.Columns {
+		if fks.Exists(column.PGName) && column.Nullable {
+			target = "o|"
+		}
+	}
+
+	return template.HTML(fmt.Sprintf(`%s }o--%s %s : "%s"`,
+		diagramName(entity.PGSchema, entity.PGName),
+		target,
+		diagramName(relation.TargetPGSchema, relation.TargetPGName),
+		strings.ReplaceAll(strings.Join(relation.FKFields, ", "), `"`, "'"),
+	))
+}
+
+// diagramName gets name of entity in mermaid diagram, schema is prefixed for tables not from public
+func diagramName(schema, table string) string {
+	name := table
+	if schema != util.PublicSchema {
+		name = schema + "_" + table
+	}
+
+	return mermaidNameRe.ReplaceAllString(name, "_")
+}
+
+// tableLink gets link to table section, tables from other schemas are in their own files
+func tableLink(schema, table, current string) string {
+	if schema == current {
+		return "#" + anchor(table)
+	}
+
+	return fmt.Sprintf("%s.md#%s", schema, anchor(table))
+}
+
+// anchor gets anchor of heading like github does
+func anchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// escape makes text safe for markdown table cell
+func escape(s string) string {
+	return markdownEscaping.Replace(s)
+}
@@ -0,0 +1,15 @@
+package docs
+
+import (
+	"github.com/dizzyfool/genna/generators/base"
+)
+
+// Options for generator
+type Options struct {
+	base.Options
+}
+
+// Def fills default values of an options
+func (o *Options) Def() {
+	o.Options.Def()
+}
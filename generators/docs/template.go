@@ -0,0 +1,29 @@
+package docs
+
+// Template is used for markdown document of schema
+const Template = `<!-- Code generated by genna. DO NOT EDIT. -->
+
+# Schema {{.Schema}}
+
+` + "```mermaid" + `
+erDiagram{{range .Entities}}
+    {{.DiagramName}} {{"{"}}{{range .Columns}}
+        {{.DiagramType}} {{.DiagramName}}{{if .DiagramKey}} {{.DiagramKey}}{{end}}{{end}}
+    }{{end}}{{range .Relations}}
+    {{.}}{{end}}
+` + "```" + `
+
+## Tables
+{{range .Entities}}
+- [{{.PGName}}](#{{.Anchor}}){{end}}
+{{range .Entities}}
+### {{.PGName}}
+{{if .IsView}}
+View, read-only
+{{end}}{{if .Description}}
+{{.Description}}
+{{end}}
+| Column | Type | Nullable | Default | Key | Values | Comment |
+|--------|------|----------|---------|-----|--------|---------|{{range .Columns}}
+| {{.Name}} | {{.Type}} | {{.IsNullable}} | {{.Default}} | {{.Key}} | {{.Values}} | {{.Comment}} |{{end}}
+{{end}}`
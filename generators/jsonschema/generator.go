@@ -0,0 +1,88 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/spf13/cobra"
+)
+
+const openAPI = "openapi"
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("jsonschema", "JSON Schema and OpenAPI components generator", New())
+}
+
+// JSONSchema represents json schema generator
+type JSONSchema struct {
+	options Options
+}
+
+// New creates generator
+func New() *JSONSchema {
+	return &JSONSchema{}
+}
+
+// Options gets options
+func (g *JSONSchema) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *JSONSchema) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *JSONSchema) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.Bool(openAPI, false, "generate OpenAPI 3.1 document with schemas in components\npackage is used as title of document")
+}
+
+// ReadFlags read flags from command
+func (g *JSONSchema) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if g.options.Options, g.options.Package, err = base.ReadFlags(command); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.OpenAPI, err = flags.GetBool(openAPI); err != nil {
+		return err
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *JSONSchema) Generate() error {
+	if g.options.MultiFile || g.options.SchemaPackage {
+		return fmt.Errorf("multi-file and schema package output are not supported by jsonschema generator")
+	}
+
+	return base.NewGeneratorFromOptions(g.options.Options).Generate(
+		g.options.Options,
+		false,
+		Template,
+		g.Packer(),
+	)
+}
+
+// Packer returns packer function for compile entities into package
+func (g *JSONSchema) Packer() base.Packer {
+	return func(entities []model.Entity) (interface{}, error) {
+		return NewTemplatePackage(entities, g.options)
+	}
+}
@@ -0,0 +1,282 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+const (
+	// Dialect is JSON Schema version of generated schemas
+	Dialect = "https://json-schema.org/draft/2020-12/schema"
+	// OpenAPIVersion is version of generated OpenAPI document
+	OpenAPIVersion = "3.1.0"
+
+	typeString  = "string"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeBoolean = "boolean"
+	typeObject  = "object"
+	typeArray   = "array"
+	typeNull    = "null"
+)
+
+var numberRe = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][-+]?\d+)?$`)
+
+// TemplatePackage stores document info
+type TemplatePackage struct {
+	Package string
+	OpenAPI bool
+
+	Entities []TemplateEntity
+
+	// JSON is whole document
+	JSON template.HTML
+}
+
+// NewTemplatePackage creates a document for template
+func NewTemplatePackage(entities []model.Entity, options Options) (TemplatePackage, error) {
+	schemas := NewObject()
+
+	models := make([]TemplateEntity, len(entities))
+	for i, entity := range entities {
+		models[i] = NewTemplateEntity(entity)
+		schemas.Set(entity.GoName, models[i].Schema)
+	}
+
+	document := NewObject().
+		Set("$schema", Dialect).
+		Set("$defs", schemas)
+
+	if options.OpenAPI {
+		document = NewObject().
+			Set("openapi", OpenAPIVersion).
+			Set("info", NewObject().Set("title", options.Package).Set("version", "1.0.0")).
+			Set("jsonSchemaDialect", Dialect).
+			Set("components", NewObject().Set("schemas", schemas))
+	}
+
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return TemplatePackage{}, fmt.Errorf("marshaling schema error: %w", err)
+	}
+
+	return TemplatePackage{
+		Package: options.Package,
+		OpenAPI: options.OpenAPI,
+
+		Entities: models,
+
+		JSON: template.HTML(content),
+	}, nil
+}
+
+// TemplateEntity stores entity schema
+type TemplateEntity struct {
+	model.Entity
+
+	Schema *Object
+}
+
+// NewTemplateEntity creates schema of entity
+// properties are named as json tags of models, columns without default value and not null are required
+func NewTemplateEntity(entity model.Entity) TemplateEntity {
+	properties := NewObject()
+	required := []string{}
+
+	for _, column := range entity.Columns {
+		name := util.Underscore(column.PGName)
+		properties.Set(name, ColumnSchema(column))
+
+		if !column.Nullable && !column.HasDefault {
+			required = append(required, name)
+		}
+	}
+
+	schema := NewObject().
+		Set("type", typeObject).
+		Set("properties", properties)
+
+	if len(required) > 0 {
+		schema.Set("required", required)
+	}
+
+	return TemplateEntity{
+		Entity: entity,
+		Schema: schema,
+	}
+}
+
+// ColumnSchema gets schema of column value
+// arrays are nested by dimensions, nullable columns allow null type
+func ColumnSchema(column model.Column) *Object {
+	schema := scalarSchema(column)
+
+	if value, ok := defaultValue(column, schema); ok {
+		schema.Set("default", value)
+	}
+
+	if column.IsArray {
+		dims := column.Dimensions
+		if dims == 0 {
+			dims = 1
+		}
+
+		for i := 0; i < dims; i++ {
+			schema = NewObject().Set("type", typeArray).Set("items", schema)
+		}
+	}
+
+	if column.Nullable {
+		if typ, ok := schema.values["type"].(string); ok {
+			schema.Set("type", []string{typ, typeNull})
+		}
+
+		if values, ok := schema.values["enum"].([]interface{}); ok {
+			schema.Set("enum", append(values, nil))
+		}
+	}
+
+	return schema
+}
+
+// scalarSchema gets schema of value as it is marshaled by encoding/json from model field
+func scalarSchema(column model.Column) *Object {
+	schema := NewObject()
+
+	switch column.PGType {
+	case model.TypePGInt2, model.TypePGInt4:
+		schema.Set("type", typeInteger).Set("format", "int32")
+	case model.TypePGInt8:
+		schema.Set("type", typeInteger).Set("format", "int64")
+	case model.TypePGFloat4:
+		schema.Set("type", typeNumber).Set("format", "float")
+	case model.TypePGFloat8:
+		schema.Set("type", typeNumber).Set("format", "double")
+	case model.TypePGNumeric:
+		// decimal.Decimal and string set by --decimal or custom types are marshaled as strings
+		if column.GoType != model.TypeFloat64 && column.GoType != model.TypeFloat32 {
+			schema.Set("type", typeString)
+		} else {
+			schema.Set("type", typeNumber)
+		}
+	case model.TypePGBool:
+		schema.Set("type", typeBoolean)
+	case model.TypePGText, model.TypePGVarchar, model.TypePGBpchar, model.TypePGPoint, model.TypePGInet, model.TypePGCidr:
+		schema.Set("type", typeString)
+	case model.TypePGUuid:
+		schema.Set("type", typeString).Set("format", "uuid")
+	case model.TypePGTimestamp, model.TypePGTimestamptz, model.TypePGDate, model.TypePGTime, model.TypePGTimetz:
+		// time.Time is marshaled with date and time parts even for dates and times
+		schema.Set("type", typeString).Set("format", "date-time")
+	case model.TypePGInterval:
+		// time.Duration is marshaled as nanoseconds
+		schema.Set("type", typeInteger).Set("format", "int64")
+	case model.TypePGBytea:
+		schema.Set("type", typeString).Set("contentEncoding", "base64")
+	case model.TypePGJSON, model.TypePGJSONB:
+		schema.Set("type", typeObject)
+	case model.TypePGHstore:
+		schema.Set("type", typeObject).Set("additionalProperties", NewObject().Set("type", typeString))
+	}
+
+	if len(column.Values) > 0 {
+		values := make([]interface{}, len(column.Values))
+		for i, value := range column.Values {
+			values[i] = value
+		}
+		schema.Set("type", typeString).Set("enum", values)
+	}
+
+	if column.MaxLen > 0 && schema.values["type"] == typeString {
+		schema.Set("maxLength", column.MaxLen)
+	}
+
+	return schema
+}
+
+// defaultValue gets json value of column default if it is a literal of schema type
+// expressions like now() or nextval() are skipped
+func defaultValue(column model.Column, schema *Object) (interface{}, bool) {
+	if !column.HasDefault || column.IsArray {
+		return nil, false
+	}
+
+	literal, quoted, ok := parseLiteral(column.Default)
+	if !ok {
+		return nil, false
+	}
+
+	switch schema.values["type"] {
+	case typeString:
+		if !quoted {
+			return nil, false
+		}
+		return literal, true
+	case typeInteger:
+		if value, err := strconv.ParseInt(literal, 10, 64); err == nil {
+			return value, true
+		}
+	case typeNumber:
+		if value, err := strconv.ParseFloat(literal, 64); err == nil {
+			return value, true
+		}
+	case typeBoolean:
+		if value, err := strconv.ParseBool(literal); err == nil {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// parseLiteral parses default value of column: quoted string, number or boolean with optional cast
+// e.g. 'new'::order_status, (-1), false
+func parseLiteral(def string) (string, bool, bool) {
+	def = strings.TrimSpace(def)
+	for strings.HasPrefix(def, "(") && strings.HasSuffix(def, ")") {
+		def = strings.TrimSpace(def[1 : len(def)-1])
+	}
+
+	if strings.HasPrefix(def, "'") {
+		var value strings.Builder
+		for i := 1; i < len(def); i++ {
+			if def[i] != '\'' {
+				value.WriteByte(def[i])
+				continue
+			}
+
+			// escaped quote
+			if i+1 < len(def) && def[i+1] == '\'' {
+				value.WriteByte('\'')
+				i++
+				continue
+			}
+
+			rest := def[i+1:]
+			if rest != "" && !strings.HasPrefix(rest, "::") {
+				return "", false, false
+			}
+
+			return value.String(), true, true
+		}
+
+		return "", false, false
+	}
+
+	if i := strings.Index(def, "::"); i >= 0 {
+		def = def[:i]
+	}
+
+	if def == "true" || def == "false" || numberRe.MatchString(def) {
+		return def, false, true
+	}
+
+	return "", false, false
+}
@@ -0,0 +1,88 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func TestColumnSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		column model.Column
+		want   string
+	}{
+		{
+			name:   "Should set max length",
+			column: model.NewColumn("email", model.TypePGVarchar, "", false, false, false, false, 0, false, false, 64, nil, 10, nil),
+			want:   `{"type":"string","maxLength":64}`,
+		},
+		{
+			name:   "Should allow null for nullable enum",
+			column: model.NewColumn("status", model.TypePGVarchar, "'new'::status", true, true, false, false, 0, false, false, 0, []string{"new", "done"}, 10, nil),
+			want:   `{"type":["string","null"],"enum":["new","done",null],"default":"new"}`,
+		},
+		{
+			name:   "Should nest items of multidimensional array",
+			column: model.NewColumn("coords", model.TypePGInt4, "", false, false, false, true, 2, false, false, 0, nil, 10, nil),
+			want:   `{"type":"array","items":{"type":"array","items":{"type":"integer","format":"int32"}}}`,
+		},
+		{
+			name:   "Should skip default expressions",
+			column: model.NewColumn("createdAt", model.TypePGTimestamptz, "now()", true, false, false, false, 0, false, false, 0, nil, 10, nil),
+			want:   `{"type":"string","format":"date-time"}`,
+		},
+		{
+			name:   "Should parse negative number default",
+			column: model.NewColumn("rating", model.TypePGInt4, "(-1)", true, false, false, false, 0, false, false, 0, nil, 10, nil),
+			want:   `{"type":"integer","format":"int32","default":-1}`,
+		},
+		{
+			name:   "Should describe float numeric as number",
+			column: model.NewColumn("price", model.TypePGNumeric, "", false, false, false, false, 0, false, false, 0, nil, 10, nil),
+			want:   `{"type":"number"}`,
+		},
+		{
+			name:   "Should describe decimal numeric as string",
+			column: model.NewColumn("price", model.TypePGNumeric, "", false, false, false, false, 0, false, false, 0, nil, 10, model.CustomTypeMapping{model.TypePGNumeric: {PGType: model.TypePGNumeric, GoType: model.TypeDecimal, GoImport: model.DecimalImport}}),
+			want:   `{"type":"string"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(ColumnSchema(tt.column))
+			if err != nil {
+				t.Errorf("ColumnSchema() error = %v", err)
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("ColumnSchema() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseLiteral(t *testing.T) {
+	tests := []struct {
+		def    string
+		want   string
+		quoted bool
+		ok     bool
+	}{
+		{"false", "false", false, true},
+		{"1.5", "1.5", false, true},
+		{"'it''s'::character varying", "it's", true, true},
+		{"'a' || 'b'", "", false, false},
+		{"nextval('users_id_seq'::regclass)", "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.def, func(t *testing.T) {
+			got, quoted, ok := parseLiteral(tt.def)
+			if got != tt.want || quoted != tt.quoted || ok != tt.ok {
+				t.Errorf("parseLiteral() = %v, %v, %v, want %v, %v, %v", got, quoted, ok, tt.want, tt.quoted, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Object is json object keeping order of keys
+type Object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewObject creates empty object
+func NewObject() *Object {
+	return &Object{values: map[string]interface{}{}}
+}
+
+// Set sets value of key, new keys are added to the end
+func (o *Object) Set(key string, value interface{}) *Object {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+
+	return o
+}
+
+// Len gets count of keys
+func (o *Object) Len() int {
+	return len(o.keys)
+}
+
+// MarshalJSON implements json.Marshaler
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
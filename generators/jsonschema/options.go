@@ -0,0 +1,28 @@
+package jsonschema
+
+import (
+	"strings"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/util"
+)
+
+// Options for generator
+type Options struct {
+	base.Options
+
+	// Package is used as title of OpenAPI document
+	Package string
+
+	// Generate OpenAPI 3.1 document with schemas in components instead of JSON Schema
+	OpenAPI bool
+}
+
+// Def fills default values of an options
+func (o *Options) Def() {
+	o.Options.Def()
+
+	if strings.Trim(o.Package, " ") == "" {
+		o.Package = util.DefaultPackage
+	}
+}
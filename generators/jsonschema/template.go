@@ -0,0 +1,5 @@
+package jsonschema
+
+// Template is used for json document, it is built in NewTemplatePackage
+const Template = `{{.JSON}}
+`
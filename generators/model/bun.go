@@ -0,0 +1,139 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+const bunTag = "bun"
+
+// bunColumnTags gets bun tags of column
+// zero values of nullable columns with defaults are written as DEFAULT,
+// not null columns keep zero values as bun writes zero values of nullzero columns as NULL on update
+func bunColumnTags(entity model.Entity, column model.Column, override base.ColumnOverride, options Options) (*util.Annotation, string) {
+	tags := util.NewAnnotation()
+
+	// ignore tag
+	if isUnsupported(column, override) {
+		return tags.AddTag(bunTag, "-"), "// unsupported"
+	}
+
+	tags.AddTag(bunTag, column.PGName)
+
+	if column.IsPK {
+		tags.AddTag(bunTag, "pk")
+		if column.HasDefault && isInteger(column) {
+			tags.AddTag(bunTag, "autoincrement")
+		}
+	}
+
+	// types tag
+	if column.PGType == model.TypePGHstore {
+		tags.AddTag(bunTag, "hstore")
+	} else if column.IsArray {
+		tags.AddTag(bunTag, "array")
+	}
+	if typ, ok := typeTag(column); ok {
+		tags.AddTag(bunTag, "type:"+typ)
+	}
+
+	if column.IsUnique {
+		tags.AddTag(bunTag, "unique")
+	}
+
+	if !column.Nullable && !column.IsPK {
+		tags.AddTag(bunTag, "notnull")
+	}
+
+	switch {
+	case isSoftDelete(entity, column, options):
+		tags.AddTag(bunTag, "soft_delete").
+			AddTag(bunTag, "nullzero")
+	case column.HasDefault && column.Nullable && !column.IsPK:
+		tags.AddTag(bunTag, "nullzero")
+	}
+
+	return tags, ""
+}
+
+// bunRelationTags gets bun tags of relation, bun joins by every pair of columns
+func bunRelationTags(relation model.Relation) (*util.Annotation, bool) {
+	tags := util.NewAnnotation()
+
+	switch relation.Type {
+	case model.HasMany:
+		if len(relation.PKFields) != len(relation.FKFields) {
+			return tags.AddTag(bunTag, "-"), false
+		}
+
+		tags.AddTag(bunTag, "rel:has-many")
+		for i, fk := range relation.FKFields {
+			tags.AddTag(bunTag, fmt.Sprintf("join:%s=%s", relation.PKFields[i], fk))
+		}
+	case model.ManyToMany:
+		// bun joins many2many relations by has-one relations of junction model
+		base, ok := junctionRelation(relation.Through, relation.FKFields)
+		target, targetOK := junctionRelation(relation.Through, relation.JoinFKFields)
+		if !ok || !targetOK {
+			return tags.AddTag(bunTag, "-"), false
+		}
+
+		tags.AddTag(bunTag, "m2m:"+relation.Through.PGFullName).
+			AddTag(bunTag, fmt.Sprintf("join:%s=%s", base, target))
+	default:
+		// has-one relation in go-pg is belongs-to relation in bun
+		if len(relation.PKFields) != len(relation.FKFields) {
+			return tags.AddTag(bunTag, "-"), false
+		}
+
+		tags.AddTag(bunTag, "rel:belongs-to")
+		for i, fk := range relation.FKFields {
+			tags.AddTag(bunTag, fmt.Sprintf("join:%s=%s", fk, relation.PKFields[i]))
+		}
+	}
+
+	return tags, true
+}
+
+// junctionRelation gets name of junction has-one relation by foreign key columns
+func junctionRelation(junction *model.Entity, fks []string) (string, bool) {
+	if junction == nil {
+		return "", false
+	}
+
+	for _, relation := range junction.Relations {
+		if relation.Type == model.HasOne && equalFields(relation.FKFields, fks) {
+			return relation.GoName, true
+		}
+	}
+
+	return "", false
+}
+
+// equalFields checks if lists of columns are equal
+func equalFields(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isInteger checks if column has integer type
+func isInteger(column model.Column) bool {
+	switch column.PGType {
+	case model.TypePGInt2, model.TypePGInt4, model.TypePGInt8:
+		return true
+	}
+
+	return false
+}
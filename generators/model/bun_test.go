@@ -0,0 +1,97 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+)
+
+func Test_bunRelationTags(t *testing.T) {
+	junction := model.NewEntity("public", "item_tags", nil, []model.Relation{
+		model.NewRelation([]string{"itemId"}, "public", "items"),
+		model.NewRelation([]string{"tagId"}, "public", "tags"),
+	})
+
+	hasOne := model.NewRelation([]string{"tenant_id", "customer_id"}, "public", "customers")
+	hasOne.SetPKFields([]string{"tenant_id", "id"})
+
+	hasMany := model.NewHasManyRelation([]string{"userId"}, "public", "orders")
+	hasMany.SetPKFields([]string{"id"})
+
+	m2m := model.NewManyToManyRelation([]string{"itemId"}, []string{"tagId"}, &junction, "public", "tags")
+
+	tests := []struct {
+		name     string
+		relation model.Relation
+		want     string
+		wantOK   bool
+	}{
+		{
+			name:     "Should join belongs-to by every column",
+			relation: hasOne,
+			want:     `bun:"rel:belongs-to,join:tenant_id=tenant_id,join:customer_id=id"`,
+			wantOK:   true,
+		},
+		{
+			name:     "Should join has-many by referenced columns",
+			relation: hasMany,
+			want:     `bun:"rel:has-many,join:id=userId"`,
+			wantOK:   true,
+		},
+		{
+			name:     "Should join many2many by junction relations",
+			relation: m2m,
+			want:     `bun:"m2m:item_tags,join:Item=Tag"`,
+			wantOK:   true,
+		},
+		{
+			name:     "Should not support unknown referenced columns",
+			relation: model.NewRelation([]string{"countryId"}, "public", "countries"),
+			want:     `bun:"-"`,
+			wantOK:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOK := bunRelationTags(tt.relation)
+			if got.String() != tt.want || gotOK != tt.wantOK {
+				t.Errorf("bunRelationTags() = %v, %v, want %v, %v", got.String(), gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_bunColumnTags(t *testing.T) {
+	entity := model.NewEntity("public", "users", nil, nil)
+
+	tests := []struct {
+		name   string
+		column model.Column
+		want   string
+	}{
+		{
+			name:   "Should not set nullzero for not null column with default",
+			column: model.NewColumn("activated", model.TypePGBool, "false", true, false, false, false, 0, false, false, 0, nil, 10, nil),
+			want:   `bun:"activated,notnull"`,
+		},
+		{
+			name:   "Should set nullzero for nullable column with default",
+			column: model.NewColumn("createdAt", model.TypePGTimestamptz, "now()", true, true, false, false, 0, false, false, 0, nil, 10, nil),
+			want:   `bun:"createdAt,nullzero"`,
+		},
+		{
+			name:   "Should set autoincrement for serial pk",
+			column: model.NewColumn("userId", model.TypePGInt4, "nextval('users_userid_seq'::regclass)", true, false, false, false, 0, true, false, 0, nil, 10, nil),
+			want:   `bun:"userId,pk,autoincrement"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := bunColumnTags(entity, tt.column, base.ColumnOverride{}, Options{})
+			if got.String() != tt.want {
+				t.Errorf("bunColumnTags() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
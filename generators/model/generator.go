@@ -14,6 +14,9 @@ const (
 	softDelete = "soft-delete"
 	json       = "json"
 	jsonTag    = "json-tag"
+	hasMany    = "has-many"
+	enums      = "enums"
+	domains    = "domains"
 )
 
 // CreateCommand creates generator command
@@ -56,14 +59,16 @@ func (g *Basic) AddFlags(command *cobra.Command) {
 
 	flags.StringToStringP(json, "j", map[string]string{"*": "map[string]interface{}"}, "type for json columns\nuse format: table.column=type, separate by comma\nuse asterisk as wildcard in table name")
 	flags.Bool(jsonTag, false, "add json tag to annotations")
+	flags.Bool(hasMany, false, "add has-many and many2many relations to referenced models, go-pg v10 and bun only")
+	flags.Bool(enums, false, "generate named types with constants for enums")
+	flags.Bool(domains, false, "generate named types for domains of basic types")
 }
 
 // ReadFlags read flags from command
 func (g *Basic) ReadFlags(command *cobra.Command) error {
 	var err error
 
-	g.options.URL, g.options.Output, g.options.Package, g.options.Tables, g.options.FollowFKs, g.options.GoPgVer, g.options.CustomTypes, err = base.ReadFlags(command)
-	if err != nil {
+	if g.options.Options, g.options.Package, err = base.ReadFlags(command); err != nil {
 		return err
 	}
 
@@ -93,6 +98,18 @@ func (g *Basic) ReadFlags(command *cobra.Command) error {
 		return err
 	}
 
+	if g.options.HasMany, err = flags.GetBool(hasMany); err != nil {
+		return err
+	}
+
+	if g.options.Enums, err = flags.GetBool(enums); err != nil {
+		return err
+	}
+
+	if g.options.Domains, err = flags.GetBool(domains); err != nil {
+		return err
+	}
+
 	// setting defaults
 	g.options.Def()
 
@@ -101,17 +118,22 @@ func (g *Basic) ReadFlags(command *cobra.Command) error {
 
 // Generate runs whole generation process
 func (g *Basic) Generate() error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			g.options.UseSQLNulls,
-			g.options.Output,
-			Template,
-			g.Packer(),
-			g.options.GoPgVer,
-			g.options.CustomTypes,
-		)
+	tmpl, multiTmpl := Template, MultiTemplate
+	if g.options.Target == base.TargetBun {
+		tmpl, multiTmpl = BunTemplate, BunMultiTemplate
+	}
+
+	generator := base.NewGeneratorFromOptions(g.options.Options)
+	if g.options.MultiFile {
+		return generator.GenerateMulti(g.options.Options, g.options.UseSQLNulls, multiTmpl, g.Packer())
+	}
+
+	return generator.Generate(
+		g.options.Options,
+		g.options.UseSQLNulls,
+		tmpl,
+		g.Packer(),
+	)
 }
 
 // Packer returns packer function for compile entities into package
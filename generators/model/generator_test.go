@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/dizzyfool/genna/model"
@@ -42,3 +43,68 @@ func TestGenerator_Generate(t *testing.T) {
 		return
 	}
 }
+
+func TestGenerator_GenerateMulti(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	output := path.Join(os.TempDir(), "model_multi_test")
+	if err := os.RemoveAll(output); err != nil {
+		t.Errorf("cleaning output error = %v", err)
+		return
+	}
+
+	generator := New()
+
+	generator.options.Def()
+	generator.options.DDL = path.Join(path.Dir(filename), "..", "..", "test_db.sql")
+	generator.options.Output = output
+	generator.options.MultiFile = true
+	generator.options.Package = "model"
+	generator.options.Tables = []string{"shop.*"}
+	generator.options.FollowFKs = true
+
+	if err := generator.Generate(); err != nil {
+		t.Errorf("generate error = %v", err)
+		return
+	}
+
+	for _, name := range []string{"model.go", "user_model.go", "shop_order_model.go"} {
+		if _, err := os.Stat(path.Join(output, name)); err != nil {
+			t.Errorf("file %s not generated = %v", name, err)
+		}
+	}
+
+	t.Run("Should render comments as doc comments", func(t *testing.T) {
+		generated, err := ioutil.ReadFile(path.Join(output, "shop_order_model.go"))
+		if err != nil {
+			t.Errorf("file not generated = %v", err)
+			return
+		}
+
+		for _, doc := range []string{
+			"// Orders placed by buyers\ntype ShopOrder struct {",
+			"\t// Current status of the order\n\tStatus ",
+		} {
+			if !strings.Contains(string(generated), doc) {
+				t.Errorf("generated does not contain %q", doc)
+			}
+		}
+	})
+
+	t.Run("Should remove stale files", func(t *testing.T) {
+		generator.options.Tables = []string{"shop.items"}
+		generator.options.FollowFKs = false
+
+		if err := generator.Generate(); err != nil {
+			t.Errorf("generate error = %v", err)
+			return
+		}
+
+		if _, err := os.Stat(path.Join(output, "shop_order_model.go")); !os.IsNotExist(err) {
+			t.Errorf("stale file not removed = %v", err)
+		}
+
+		if _, err := os.Stat(path.Join(output, "shop_item_model.go")); err != nil {
+			t.Errorf("file not generated = %v", err)
+		}
+	})
+}
@@ -3,8 +3,11 @@ package model
 import (
 	"fmt"
 	"html/template"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/dizzyfool/genna/generators/base"
 	"github.com/dizzyfool/genna/model"
 	"github.com/dizzyfool/genna/util"
 )
@@ -17,11 +20,34 @@ type TemplatePackage struct {
 	Imports    []string
 
 	Entities []TemplateEntity
+
+	// Junctions are entities used by many2many relations, should be registered in orm
+	HasJunctions bool
+	Junctions    []string
+
+	HasEnums bool
+	Enums    []TemplateEnum
+
+	HasDomains bool
+	Domains    []TemplateDomain
+
+	HasComposites bool
+	Composites    []TemplateComposite
 }
 
 // NewTemplatePackage creates a package for template
 func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackage {
 	imports := util.NewSet()
+	junctions := util.NewSet()
+
+	var enums []TemplateEnum
+	enumsIndex := util.NewSet()
+
+	var domains []TemplateDomain
+	domainsIndex := util.NewSet()
+
+	var composites []TemplateComposite
+	compositesIndex := util.NewSet()
 
 	models := make([]TemplateEntity, len(entities))
 	for i, entity := range entities {
@@ -30,15 +56,72 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 		}
 
 		models[i] = NewTemplateEntity(entity, options)
+
+		if options.Enums {
+			for _, column := range entity.Columns {
+				if column.Enum != nil && enumsIndex.Add(column.Enum.PGFullName) {
+					enums = append(enums, NewTemplateEnum(*column.Enum))
+				}
+			}
+		}
+
+		for _, column := range entity.Columns {
+			if usesComposite(column, options) {
+				composites = addComposite(composites, &compositesIndex, &imports, *column.Composite, options)
+			}
+		}
+
+		if options.Domains {
+			for _, column := range entity.Columns {
+				if _, ok := domainType(column); ok && domainsIndex.Add(column.Domain.PGFullName) {
+					domains = append(domains, TemplateDomain{Domain: *column.Domain, Type: column.GoType})
+				}
+			}
+		}
+
+		for _, relation := range models[i].Relations {
+			if relation.Through != nil {
+				junctions.Add(relation.Through.GoName)
+			}
+		}
+	}
+
+	if options.Target == base.TargetBun {
+		imports.Add("github.com/uptrace/bun")
+	} else if junctions.Len() > 0 {
+		imports.Add(fmt.Sprintf("github.com/go-pg/pg/v%d/orm", options.GoPgVer))
+	}
+
+	if len(enums) > 0 {
+		imports.Add("database/sql/driver")
+		imports.Add("fmt")
+	}
+
+	if len(composites) > 0 && options.Target == base.TargetBun {
+		for _, imp := range compositeImports {
+			imports.Add(imp)
+		}
 	}
 
 	return TemplatePackage{
-		Package: options.Package,
+		Package: base.PackageName(options.Options, options.Package, entities),
 
 		HasImports: imports.Len() > 0,
 		Imports:    imports.Elements(),
 
 		Entities: models,
+
+		HasJunctions: junctions.Len() > 0,
+		Junctions:    junctions.Elements(),
+
+		HasEnums: len(enums) > 0,
+		Enums:    enums,
+
+		HasDomains: len(domains) > 0,
+		Domains:    domains,
+
+		HasComposites: len(composites) > 0,
+		Composites:    composites,
 	}
 }
 
@@ -46,6 +129,7 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 type TemplateEntity struct {
 	model.Entity
 
+	Doc template.HTML
 	Tag template.HTML
 
 	NoAlias bool
@@ -68,14 +152,21 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 		columns[i] = NewTemplateColumn(entity, column, options)
 	}
 
-	relations := make([]TemplateRelation, len(entity.Relations))
-	for i, relation := range entity.Relations {
-		relations[i] = NewTemplateRelation(relation, options)
+	var relations []TemplateRelation
+	for _, relation := range entity.Relations {
+		// inverse relations are supported only by go-pg v10 and bun
+		if relation.Type != model.HasOne && (!options.HasMany || options.Target == base.TargetGoPG && options.GoPgVer < 10) {
+			continue
+		}
+
+		relations = append(relations, NewTemplateRelation(relation, options))
 	}
 
 	tagName := tagName(options)
 	tags := util.NewAnnotation()
-	if options.GoPgVer < 10 {
+	if options.Target == base.TargetBun {
+		tags.AddTag(tagName, "table:"+entity.PGFullName)
+	} else if options.GoPgVer < 10 {
 		tags.AddTag(tagName, util.Quoted(entity.PGFullName, true))
 	} else {
 		tags.AddTag(tagName, entity.PGFullName)
@@ -85,7 +176,8 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 		tags.AddTag(tagName, fmt.Sprintf("alias:%s", util.DefaultAlias))
 	}
 
-	if !options.NoDiscard {
+	// bun discards unknown columns by db option
+	if !options.NoDiscard && options.Target != base.TargetBun {
 		if options.GoPgVer == 8 {
 			tags.AddTag("pg", "")
 		}
@@ -94,6 +186,7 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 
 	return TemplateEntity{
 		Entity: entity,
+		Doc:    base.DocComment(entity.Comment),
 		Tag:    template.HTML(fmt.Sprintf("`%s`", tags.String())),
 
 		NoAlias: options.NoAlias,
@@ -110,19 +203,34 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 type TemplateColumn struct {
 	model.Column
 
+	Doc     template.HTML
 	Tag     template.HTML
 	Comment template.HTML
 }
 
 // NewTemplateColumn creates a column for template
 func NewTemplateColumn(entity model.Entity, column model.Column, options Options) TemplateColumn {
-	if !options.KeepPK && column.IsPK {
-		column.GoName = util.ID
+	column.GoName = options.Overrides.FieldName(entity, column, options.KeepPK)
+
+	override := options.Overrides.Column(entity.PGSchema, entity.PGName, column.PGName)
+
+	// type set in config is already applied to column and wins over generated types
+	if override.Type != "" {
+		column.Composite = nil
+	} else {
+		column.Type = columnType(entity, column, override, options)
 	}
 
-	if column.PGType == model.TypePGJSON || column.PGType == model.TypePGJSONB {
-		if typ, ok := jsonType(options.JSONTypes, entity.PGSchema, entity.PGName, column.PGName); ok {
-			column.Type = typ
+	if options.Target == base.TargetBun {
+		tags, comment := bunColumnTags(entity, column, override, options)
+		addTags(tags, column, override, options)
+
+		return TemplateColumn{
+			Column: column,
+
+			Doc:     base.DocComment(column.Comment),
+			Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
+			Comment: template.HTML(comment),
 		}
 	}
 
@@ -142,8 +250,16 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 	} else if column.IsArray {
 		tags.AddTag(tagName, "array")
 	}
-	if column.PGType == model.TypePGUuid {
-		tags.AddTag(tagName, "type:uuid")
+	if typ, ok := typeTag(column); ok {
+		tags.AddTag(tagName, "type:"+typ)
+	}
+	if column.Composite != nil {
+		tags.AddTag(tagName, compositeTag(column))
+	}
+
+	// unique tag
+	if column.IsUnique {
+		tags.AddTag(tagName, "unique")
 	}
 
 	// nullable tag
@@ -156,33 +272,109 @@ func NewTemplateColumn(entity model.Entity, column model.Column, options Options
 	}
 
 	// soft_delete tag
-	if options.SoftDelete == column.PGName && column.Nullable && column.GoType == model.TypeTime && !column.IsArray {
+	if isSoftDelete(entity, column, options) {
 		tags.AddTag("pg", ",soft_delete")
 	}
 
 	// ignore tag
-	if column.GoType == model.TypeInterface {
+	if isUnsupported(column, override) {
 		comment = "// unsupported"
 		tags = util.NewAnnotation().AddTag(tagName, "-")
 	}
 
-	// add json tag
-	if options.AddJSONTag {
-		tags.AddTag("json", util.Underscore(column.PGName))
-	}
+	addTags(tags, column, override, options)
 
 	return TemplateColumn{
 		Column: column,
 
+		Doc:     base.DocComment(column.Comment),
 		Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
 		Comment: template.HTML(comment),
 	}
 }
 
+// isUnsupported checks if column type has no go counterpart and is not set in config, such columns are ignored
+func isUnsupported(column model.Column, override base.ColumnOverride) bool {
+	return column.GoType == model.TypeInterface && column.Composite == nil && override.Type == ""
+}
+
+// columnType gets go type of column in model, json, enum, domain and composite types replace type by database type
+func columnType(entity model.Entity, column model.Column, override base.ColumnOverride, options Options) string {
+	if column.PGType == model.TypePGJSON || column.PGType == model.TypePGJSONB {
+		if override.JSON != "" {
+			column.Type = override.JSON
+		} else if typ, ok := jsonType(options.JSONTypes, entity.PGSchema, entity.PGName, column.PGName); ok {
+			column.Type = typ
+		}
+	}
+
+	if options.Enums && column.Enum != nil {
+		column.Type = enumType(*column.Enum, column)
+	}
+
+	if typ, ok := domainType(column); ok && options.Domains {
+		column.Type = typ
+	}
+
+	if column.Composite != nil {
+		column.Type = compositeType(*column.Composite, column, options)
+	}
+
+	return column.Type
+}
+
+// isSoftDelete checks if column is soft delete column of entity set by flag or config
+func isSoftDelete(entity model.Entity, column model.Column, options Options) bool {
+	softDelete := options.SoftDelete
+	if table := options.Overrides.Table(entity.PGSchema, entity.PGName); table.SoftDelete != "" {
+		softDelete = table.SoftDelete
+	}
+
+	return softDelete == column.PGName && column.Nullable && column.GoType == model.TypeTime && !column.IsArray
+}
+
+// typeTag gets pg type for type tag, it is set for types which can not be detected by go type
+func typeTag(column model.Column) (string, bool) {
+	typ, ok := model.TypeTag(column.PGType)
+	if !ok {
+		return "", false
+	}
+
+	if column.IsArray {
+		dims := column.Dimensions
+		if dims == 0 {
+			dims = 1
+		}
+		typ += strings.Repeat("[]", dims)
+	}
+
+	return typ, true
+}
+
+// addTags adds json tag and additional tags from config
+func addTags(tags *util.Annotation, column model.Column, override base.ColumnOverride, options Options) {
+	// add json tag
+	if options.AddJSONTag {
+		tags.AddTag("json", util.Underscore(column.PGName))
+	}
+
+	// additional tags from config
+	names := make([]string, 0, len(override.Tags))
+	for name := range override.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tags.AddTag(name, override.Tags[name])
+	}
+}
+
 // TemplateRelation stores relation info
 type TemplateRelation struct {
 	model.Relation
 
+	FieldType string
+
 	Tag     template.HTML
 	Comment template.HTML
 }
@@ -191,12 +383,56 @@ type TemplateRelation struct {
 func NewTemplateRelation(relation model.Relation, options Options) TemplateRelation {
 	comment := ""
 	tagName := tagName(options)
-	tags := util.NewAnnotation().AddTag("pg", "fk:"+strings.Join(relation.FKFields, ","))
-	if options.GoPgVer >= 10 {
-		tags.AddTag("pg", "rel:has-one")
+	fieldType := "*" + relation.GoType
+	if relation.Type != model.HasOne {
+		fieldType = "[]" + relation.GoType
 	}
 
-	if len(relation.FKFields) > 1 {
+	if options.Target == base.TargetBun {
+		tags, ok := bunRelationTags(relation)
+		if !ok {
+			comment = "// unsupported"
+		}
+
+		// add json tag
+		if options.AddJSONTag {
+			tags.AddTag("json", util.Underscore(relation.GoName))
+		}
+
+		return TemplateRelation{
+			Relation: relation,
+
+			FieldType: fieldType,
+
+			Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
+			Comment: template.HTML(comment),
+		}
+	}
+
+	tags := util.NewAnnotation()
+	fk, fkOK := joinFK(relation.FKFields, relation.PKFields)
+	switch relation.Type {
+	case model.HasMany:
+		tags.AddTag("pg", "rel:has-many").
+			AddTag("pg", "join_fk:"+fk)
+	case model.ManyToMany:
+		joinFK, joinFKOK := joinFK(relation.JoinFKFields, relation.JoinPKFields)
+		fkOK = fkOK && joinFKOK
+
+		tags.AddTag("pg", "many2many:"+relation.Through.PGFullName).
+			AddTag("pg", "fk:"+fk).
+			AddTag("pg", "join_fk:"+joinFK)
+	default:
+		// go-pg joins composite has-one relations only by primary key
+		fkOK = fkOK && (len(relation.FKFields) == 1 || relation.ReferencesPK())
+
+		tags.AddTag("pg", "fk:"+fk)
+		if options.GoPgVer >= 10 {
+			tags.AddTag("pg", "rel:has-one")
+		}
+	}
+
+	if !fkOK {
 		comment = "// unsupported"
 		tags.AddTag(tagName, "-")
 	}
@@ -209,6 +445,8 @@ func NewTemplateRelation(relation model.Relation, options Options) TemplateRelat
 	return TemplateRelation{
 		Relation: relation,
 
+		FieldType: fieldType,
+
 		Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
 		Comment: template.HTML(comment),
 	}
@@ -243,8 +481,214 @@ func jsonType(mp map[string]string, schema, table, field string) (string, bool)
 }
 
 func tagName(options Options) string {
+	if options.Target == base.TargetBun {
+		return "bun"
+	}
 	if options.GoPgVer == 8 {
 		return "sql"
 	}
 	return "pg"
 }
+
+// TemplateEnum stores enum info
+type TemplateEnum struct {
+	model.Enum
+
+	Constants []TemplateEnumConstant
+}
+
+// TemplateEnumConstant stores enum value info
+type TemplateEnumConstant struct {
+	GoName string
+	Value  template.HTML
+}
+
+// NewTemplateEnum creates enum for template
+func NewTemplateEnum(enum model.Enum) TemplateEnum {
+	names := enum.ValueGoNames()
+	constants := make([]TemplateEnumConstant, len(enum.Values))
+	for i, value := range enum.Values {
+		constants[i] = TemplateEnumConstant{
+			GoName: names[i],
+			Value:  template.HTML(strconv.Quote(value)),
+		}
+	}
+
+	return TemplateEnum{
+		Enum:      enum,
+		Constants: constants,
+	}
+}
+
+// enumType gets go type of column with enum type
+func enumType(enum model.Enum, column model.Column) string {
+	if column.IsArray {
+		return strings.Repeat("[]", column.Dimensions) + enum.GoName
+	}
+
+	if column.Nullable {
+		return "*" + enum.GoName
+	}
+
+	return enum.GoName
+}
+
+// TemplateDomain stores domain info
+type TemplateDomain struct {
+	model.Domain
+
+	// Type is go type of domain base type
+	Type string
+}
+
+// domainType gets go type of column with domain type, only domains of basic go types get named types
+func domainType(column model.Column) (string, bool) {
+	if column.Domain == nil || column.Enum != nil || column.IsArray {
+		return "", false
+	}
+
+	switch column.GoType {
+	case model.TypeString, model.TypeBool,
+		model.TypeInt, model.TypeInt32, model.TypeInt64,
+		model.TypeFloat32, model.TypeFloat64:
+	default:
+		return "", false
+	}
+
+	// sql.Null types and overridden types are kept
+	switch column.Type {
+	case column.GoType:
+		return column.Domain.GoName, true
+	case "*" + column.GoType:
+		return "*" + column.Domain.GoName, true
+	}
+
+	return "", false
+}
+
+// compositeImports are used by Scan and Value methods of composite types, go-pg reads composite types natively
+var compositeImports = []string{"database/sql/driver", "github.com/dizzyfool/genna/composite"}
+
+// TemplateComposite stores composite type info
+type TemplateComposite struct {
+	model.Composite
+
+	Fields []TemplateColumn
+}
+
+// NewTemplateComposite creates composite type for template
+func NewTemplateComposite(composite model.Composite, options Options) TemplateComposite {
+	fields := make([]TemplateColumn, len(composite.Fields))
+	for i, field := range composite.Fields {
+		if field.Composite != nil {
+			field.Type = compositeType(*field.Composite, field, options)
+		}
+
+		tags := util.NewAnnotation()
+		if options.Target == base.TargetBun {
+			tags.AddTag(bunTag, field.PGName)
+			if field.IsArray {
+				tags.AddTag(bunTag, "array")
+			}
+		} else {
+			tagName := tagName(options)
+			tags.AddTag(tagName, field.PGName)
+			if field.IsArray {
+				tags.AddTag(tagName, "array")
+			}
+			if typ, ok := typeTag(field); ok {
+				tags.AddTag(tagName, "type:"+typ)
+			}
+			if field.Composite != nil {
+				tags.AddTag(tagName, compositeTag(field))
+			}
+		}
+
+		fields[i] = TemplateColumn{
+			Column: field,
+			Tag:    template.HTML(fmt.Sprintf("`%s`", tags.String())),
+		}
+	}
+
+	return TemplateComposite{
+		Composite: composite,
+		Fields:    fields,
+	}
+}
+
+// addComposite adds composite type and composite types of its fields if they are not added yet
+func addComposite(composites []TemplateComposite, index, imports *util.Set, composite model.Composite, options Options) []TemplateComposite {
+	if !index.Add(composite.PGFullName) {
+		return composites
+	}
+
+	composites = append(composites, NewTemplateComposite(composite, options))
+	for _, field := range composite.Fields {
+		if field.Import != "" {
+			imports.Add(field.Import)
+		}
+		if usesComposite(field, options) {
+			composites = addComposite(composites, index, imports, *field.Composite, options)
+		}
+	}
+
+	return composites
+}
+
+// usesComposite checks if column is generated with struct of its composite type
+func usesComposite(column model.Column, options Options) bool {
+	return column.Composite != nil && (!column.IsArray || options.Target == base.TargetBun)
+}
+
+// compositeType gets go type of column with composite type
+// go-pg has no scanner for arrays of composite types, so they are generated as arrays of composite literals
+func compositeType(composite model.Composite, column model.Column, options Options) string {
+	if column.IsArray {
+		if options.Target != base.TargetBun {
+			return strings.Repeat("[]", column.Dimensions) + model.TypeString
+		}
+		return strings.Repeat("[]", column.Dimensions) + composite.GoName
+	}
+
+	if column.Nullable {
+		return "*" + composite.GoName
+	}
+
+	return composite.GoName
+}
+
+// compositeTag gets go-pg tag of column with composite type, composite columns are read natively, arrays as composite literals
+func compositeTag(column model.Column) string {
+	if column.IsArray {
+		return "type:" + column.Composite.PGFullName + strings.Repeat("[]", column.Dimensions)
+	}
+
+	return "composite:" + column.Composite.PGFullName
+}
+
+// joinFK gets value of fk (join_fk) tag for foreign key columns
+// go-pg joins composite keys by prefix: every column should be named as prefix + referenced column or as referenced column
+func joinFK(fks, pks []string) (string, bool) {
+	if len(fks) == 1 {
+		return fks[0], true
+	}
+
+	if len(fks) != len(pks) {
+		return strings.Join(fks, ","), false
+	}
+
+	prefix := ""
+	for i, fk := range fks {
+		if fk == pks[i] {
+			continue
+		}
+
+		if !strings.HasSuffix(fk, pks[i]) || (prefix != "" && prefix != strings.TrimSuffix(fk, pks[i])) {
+			return strings.Join(fks, ","), false
+		}
+
+		prefix = strings.TrimSuffix(fk, pks[i])
+	}
+
+	return prefix, true
+}
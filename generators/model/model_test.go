@@ -0,0 +1,215 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+func Test_joinFK(t *testing.T) {
+	tests := []struct {
+		name   string
+		fks    []string
+		pks    []string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "Should use single column",
+			fks:    []string{"customerId"},
+			pks:    []string{"id"},
+			want:   "customerId",
+			wantOK: true,
+		},
+		{
+			name:   "Should use prefix for composite key",
+			fks:    []string{"tenant_id", "customer_id"},
+			pks:    []string{"tenant_id", "id"},
+			want:   "customer_",
+			wantOK: true,
+		},
+		{
+			name:   "Should use empty prefix for same columns",
+			fks:    []string{"tenantId", "customerId"},
+			pks:    []string{"tenantId", "customerId"},
+			want:   "",
+			wantOK: true,
+		},
+		{
+			name:   "Should not support different prefixes",
+			fks:    []string{"seller_id", "buyer_code"},
+			pks:    []string{"id", "code"},
+			want:   "seller_id,buyer_code",
+			wantOK: false,
+		},
+		{
+			name:   "Should not support unknown referenced columns",
+			fks:    []string{"tenantId", "customerId"},
+			want:   "tenantId,customerId",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOK := joinFK(tt.fks, tt.pks)
+			if got != tt.want || gotOK != tt.wantOK {
+				t.Errorf("joinFK() = %v, %v, want %v, %v", got, gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_typeTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		column model.Column
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "Should not set type for text",
+			column: model.Column{PGType: model.TypePGText},
+		},
+		{
+			name:   "Should set type for tsvector",
+			column: model.Column{PGType: model.TypePGTsvector},
+			want:   "tsvector",
+			wantOK: true,
+		},
+		{
+			name:   "Should set type for uuid array",
+			column: model.Column{PGType: model.TypePGUuid, IsArray: true, Dimensions: 2},
+			want:   "uuid[][]",
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOK := typeTag(tt.column)
+			if got != tt.want || gotOK != tt.wantOK {
+				t.Errorf("typeTag() = %v, %v, want %v, %v", got, gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_domainType(t *testing.T) {
+	domain := model.NewDomain("shop", "quantity", nil)
+
+	tests := []struct {
+		name   string
+		column model.Column
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "Should use named type for domain",
+			column: model.Column{GoType: model.TypeInt32, Type: model.TypeInt32, Domain: &domain},
+			want:   "ShopQuantity",
+			wantOK: true,
+		},
+		{
+			name:   "Should use pointer to named type for nullable domain",
+			column: model.Column{GoType: model.TypeInt32, Type: "*" + model.TypeInt32, Nullable: true, Domain: &domain},
+			want:   "*ShopQuantity",
+			wantOK: true,
+		},
+		{
+			name:   "Should keep sql null type",
+			column: model.Column{GoType: model.TypeInt32, Type: "sql.NullInt32", Nullable: true, Domain: &domain},
+		},
+		{
+			name:   "Should keep type of complex domain",
+			column: model.Column{GoType: model.TypeTime, Type: model.TypeTime, Domain: &domain},
+		},
+		{
+			name:   "Should keep type without domain",
+			column: model.Column{GoType: model.TypeInt32, Type: model.TypeInt32},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOK := domainType(tt.column)
+			if got != tt.want || gotOK != tt.wantOK {
+				t.Errorf("domainType() = %v, %v, want %v, %v", got, gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_compositeType(t *testing.T) {
+	composite := model.NewComposite("shop", "address", nil)
+
+	tests := []struct {
+		name    string
+		column  model.Column
+		options Options
+		want    string
+	}{
+		{
+			name:   "Should use struct for not null column",
+			column: model.Column{},
+			want:   "ShopAddress",
+		},
+		{
+			name:   "Should use pointer for nullable column",
+			column: model.Column{Nullable: true},
+			want:   "*ShopAddress",
+		},
+		{
+			name:   "Should use strings for array",
+			column: model.Column{Nullable: true, IsArray: true, Dimensions: 2},
+			want:   "[][]string",
+		},
+		{
+			name:    "Should use slice for array with bun target",
+			column:  model.Column{Nullable: true, IsArray: true, Dimensions: 2},
+			options: Options{Options: base.Options{Target: base.TargetBun}},
+			want:    "[][]ShopAddress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compositeType(composite, tt.column, tt.options); got != tt.want {
+				t.Errorf("compositeType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateColumn_Overrides(t *testing.T) {
+	status := model.NewEnum(util.PublicSchema, "status", []string{"new", "done"})
+
+	column := model.NewColumn("status", model.TypePGVarchar, "", false, false, false, false, 0, false, false, 0, status.Values, 10, nil)
+	column.Enum = &status
+
+	entity := model.NewEntity(util.PublicSchema, "users", []model.Column{
+		model.NewColumn("userId", model.TypePGInt4, "", false, false, false, false, 0, true, false, 0, nil, 10, nil),
+		column,
+	}, nil)
+
+	options := Options{Enums: true}
+	options.Overrides = base.Overrides{
+		"users": {Columns: map[string]base.ColumnOverride{
+			"userId": {Name: "UserID"},
+			"status": {Type: "[]byte"},
+		}},
+	}
+
+	entities, err := options.Overrides.Apply([]model.Entity{entity})
+	if err != nil {
+		t.Errorf("Apply() error = %v", err)
+		return
+	}
+	entity = entities[0]
+
+	if got := NewTemplateColumn(entity, entity.Columns[0], options).GoName; got != "UserID" {
+		t.Errorf("NewTemplateColumn().GoName = %v, want %v", got, "UserID")
+	}
+
+	if got := NewTemplateColumn(entity, entity.Columns[1], options).Type; got != "[]byte" {
+		t.Errorf("NewTemplateColumn().Type = %v, want %v", got, "[]byte")
+	}
+}
@@ -12,7 +12,7 @@ type Options struct {
 	base.Options
 
 	// Package sets package name for model
-	// Works only with SchemaPackage = false
+	// Not used with SchemaPackage, every package is named after its schema
 	Package string
 
 	// Do not replace primary key name to ID
@@ -35,6 +35,15 @@ type Options struct {
 
 	// Add json tag to models
 	AddJSONTag bool
+
+	// Add has-many and many2many relations to referenced models
+	HasMany bool
+
+	// Generate named types with constants for enums
+	Enums bool
+
+	// Generate named types for domains
+	Domains bool
 }
 
 // Def fills default values of an options
@@ -1,13 +1,36 @@
 package model
 
-const Template = `//nolint
+import "github.com/dizzyfool/genna/generators/base"
+
+const Template = templateHeader + templateTables + templateModels + templateDomains + templateComposites + templateEnums + templateJunctions
+
+// MultiTemplate is used for multi-file output: Columns, Tables, domains, composite types and enums go to shared file, models to their own files
+var MultiTemplate = base.MultiTemplate{
+	Name:   "model",
+	Shared: templateHeader + templateTables + templateDomains + templateComposites + templateEnums + templateJunctions,
+	Entity: templateHeader + templateModels,
+}
+
+// BunTemplate is used for bun target
+const BunTemplate = templateHeader + templateTables + templateBunModels + templateDomains + templateBunComposites + templateEnums + templateBunJunctions
+
+// BunMultiTemplate is used for multi-file output for bun target
+var BunMultiTemplate = base.MultiTemplate{
+	Name:   "model",
+	Shared: templateHeader + templateTables + templateDomains + templateBunComposites + templateEnums + templateBunJunctions,
+	Entity: templateHeader + templateBunModels,
+}
+
+const templateHeader = `//nolint
 //lint:file-ignore U1000 ignore unused code, it's generated
 package {{.Package}}{{if .HasImports}}
 
 import ({{range .Imports}}
     "{{.}}"{{end}}
 ){{end}}
+`
 
+const templateTables = `
 var Columns = struct { {{range .Entities}}
 	{{.GoName}} struct{ 
 		{{range $i, $e := .Columns}}{{if $i}}, {{end}}{{.GoName}}{{end}} string{{if .HasRelations}}
@@ -38,13 +61,126 @@ var Tables = struct { {{range .Entities}}
 		Alias: "{{.Alias}}",{{end}}
 	},{{end}}
 }
-{{range $model := .Entities}}
-type {{.GoName}} struct {
+`
+
+const templateModels = `{{range $model := .Entities}}
+{{.Doc}}type {{.GoName}} struct {
 	tableName struct{} {{.Tag}}
 	{{range .Columns}}
-	{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{if .HasRelations}}
+	{{.Doc}}{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{if .HasRelations}}
 	{{range .Relations}}
-	{{.GoName}} *{{.GoType}} {{.Tag}} {{.Comment}}{{end}}{{end}}
+	{{.GoName}} {{.FieldType}} {{.Tag}} {{.Comment}}{{end}}{{end}}
+}
+{{end}}`
+
+const templateDomains = `{{range .Domains}}
+type {{.GoName}} {{.Type}}
+{{end}}`
+
+const templateComposites = `{{range .Composites}}
+type {{.GoName}} struct { {{range .Fields}}
+	{{.GoName}} {{.Type}} {{.Tag}}{{end}}
+}
+{{end}}`
+
+// templateBunComposites has sql.Scanner and driver.Valuer methods, as bun has no native composite types support
+const templateBunComposites = `{{range .Composites}}
+type {{.GoName}} struct { {{range .Fields}}
+	{{.GoName}} {{.Type}} {{.Tag}}{{end}}
+}
+
+// Scan implements sql.Scanner
+func (c *{{.GoName}}) Scan(src interface{}) error {
+	return composite.Scan(src{{range .Fields}}, &c.{{.GoName}}{{end}})
+}
+
+// Value implements driver.Valuer
+func (c {{.GoName}}) Value() (driver.Value, error) {
+	return composite.Value({{range $i, $e := .Fields}}{{if $i}}, {{end}}c.{{.GoName}}{{end}})
+}
+{{end}}`
+
+const templateEnums = `{{range $enum := .Enums}}
+type {{.GoName}} string
+
+const ({{range .Constants}}
+	{{.GoName}} {{$enum.GoName}} = {{.Value}}{{end}}
+)
+
+// Values gets all values of {{.GoName}}
+func ({{.GoName}}) Values() []{{.GoName}} {
+	return []{{.GoName}}{ {{range .Constants}}
+		{{.GoName}},{{end}}
+	}
 }
+
+// IsValid checks if value is one of {{.GoName}} values
+func (e {{.GoName}}) IsValid() bool {
+{{if .Constants}}	switch e {
+	case {{range $i, $e := .Constants}}{{if $i}}, {{end}}{{.GoName}}{{end}}:
+		return true
+	}
 {{end}}
-`
+	return false
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (e {{.GoName}}) MarshalText() ([]byte, error) {
+	return []byte(e), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (e *{{.GoName}}) UnmarshalText(text []byte) error {
+	value := {{.GoName}}(text)
+	if !value.IsValid() {
+		return fmt.Errorf("invalid {{.GoName}} value %q", text)
+	}
+
+	*e = value
+	return nil
+}
+
+// Scan implements sql.Scanner
+func (e *{{.GoName}}) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*e = ""
+	case string:
+		*e = {{.GoName}}(value)
+	case []byte:
+		*e = {{.GoName}}(value)
+	default:
+		return fmt.Errorf("unsupported type %T for {{.GoName}}", src)
+	}
+
+	return nil
+}
+
+// Value implements driver.Valuer
+func (e {{.GoName}}) Value() (driver.Value, error) {
+	return string(e), nil
+}
+{{end}}`
+
+const templateJunctions = `{{if .HasJunctions}}
+func init() { {{range .Junctions}}
+	orm.RegisterTable((*{{.}})(nil)){{end}}
+}
+{{end}}`
+
+const templateBunModels = `{{range $model := .Entities}}
+{{.Doc}}type {{.GoName}} struct {
+	bun.BaseModel {{.Tag}}
+	{{range .Columns}}
+	{{.Doc}}{{.GoName}} {{.Type}} {{.Tag}} {{.Comment}}{{end}}{{if .HasRelations}}
+	{{range .Relations}}
+	{{.GoName}} {{.FieldType}} {{.Tag}} {{.Comment}}{{end}}{{end}}
+}
+{{end}}`
+
+const templateBunJunctions = `{{if .HasJunctions}}
+// JunctionModels are models used by many2many relations, they should be registered by db.RegisterModel(JunctionModels...)
+var JunctionModels = []interface{}{ {{range .Junctions}}
+	(*{{.}})(nil),{{end}}
+}
+{{end}}`
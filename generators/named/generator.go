@@ -1,6 +1,8 @@
 package named
 
 import (
+	"fmt"
+
 	"github.com/dizzyfool/genna/generators/base"
 	"github.com/dizzyfool/genna/generators/model"
 
@@ -27,15 +29,19 @@ func New() *Generator {
 // Generate runs whole generation process
 func (g *Generator) Generate() error {
 	options := g.Options()
-	return base.NewGenerator(options.URL).
-		Generate(
-			options.Tables,
-			options.FollowFKs,
-			options.UseSQLNulls,
-			options.Output,
-			Template,
-			g.Packer(),
-			options.GoPgVer,
-			options.CustomTypes,
-		)
+	if options.Target != base.TargetGoPG {
+		return fmt.Errorf("target %s is not supported by model-named generator", options.Target)
+	}
+
+	generator := base.NewGeneratorFromOptions(options.Options)
+	if options.MultiFile {
+		return generator.GenerateMulti(options.Options, options.UseSQLNulls, MultiTemplate, g.Packer())
+	}
+
+	return generator.Generate(
+		options.Options,
+		options.UseSQLNulls,
+		Template,
+		g.Packer(),
+	)
 }
@@ -0,0 +1,95 @@
+package pgx
+
+import (
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	keepPK  = "keep-pk"
+	jsonTag = "json-tag"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("pgx", "Plain structs and scanners generator for pgx", New())
+}
+
+// Pgx represents pgx generator
+type Pgx struct {
+	options Options
+}
+
+// New creates generator
+func New() *Pgx {
+	return &Pgx{}
+}
+
+// Options gets options
+func (g *Pgx) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *Pgx) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *Pgx) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.BoolP(keepPK, "k", false, "keep primary key name as is (by default it should be converted to 'ID')")
+	flags.Bool(jsonTag, false, "add json tag to annotations")
+}
+
+// ReadFlags read flags from command
+func (g *Pgx) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if g.options.Options, g.options.Package, err = base.ReadFlags(command); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.KeepPK, err = flags.GetBool(keepPK); err != nil {
+		return err
+	}
+
+	if g.options.AddJSONTag, err = flags.GetBool(jsonTag); err != nil {
+		return err
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *Pgx) Generate() error {
+	generator := base.NewGeneratorFromOptions(g.options.Options)
+	if g.options.MultiFile {
+		return generator.GenerateMulti(g.options.Options, false, MultiTemplate, g.Packer())
+	}
+
+	return generator.Generate(
+		g.options.Options,
+		false,
+		Template,
+		g.Packer(),
+	)
+}
+
+// Packer returns packer function for compile entities into package
+func (g *Pgx) Packer() base.Packer {
+	return func(entities []model.Entity) (interface{}, error) {
+		return NewTemplatePackage(entities, g.options), nil
+	}
+}
@@ -0,0 +1,378 @@
+package pgx
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+const (
+	pgxImport       = "github.com/jackc/pgx/v5"
+	pgtypeImport    = "github.com/jackc/pgx/v5/pgtype"
+	compositeImport = "github.com/dizzyfool/genna/composite"
+)
+
+// TemplatePackage stores package info
+type TemplatePackage struct {
+	Package string
+
+	HasImports bool
+	Imports    []string
+
+	Entities []TemplateEntity
+
+	HasComposites bool
+	Composites    []TemplateComposite
+}
+
+// NewTemplatePackage creates a package for template
+func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackage {
+	imports := util.NewSet()
+	imports.Add(pgxImport)
+
+	var composites []TemplateComposite
+	compositesIndex := util.NewSet()
+
+	models := make([]TemplateEntity, len(entities))
+	for i, entity := range entities {
+		models[i] = NewTemplateEntity(entity, options)
+
+		for _, imp := range models[i].Imports {
+			imports.Add(imp)
+		}
+
+		for _, column := range models[i].Columns {
+			if column.Composite != nil {
+				composites = addComposite(composites, &compositesIndex, &imports, *column.Composite, options)
+			}
+		}
+	}
+
+	// composite types implement sql.Scanner and driver.Valuer
+	if len(composites) > 0 {
+		imports.Add("database/sql/driver")
+		imports.Add(compositeImport)
+	}
+
+	return TemplatePackage{
+		Package: base.PackageName(options.Options, options.Package, entities),
+
+		HasImports: imports.Len() > 0,
+		Imports:    imports.Elements(),
+
+		Entities: models,
+
+		HasComposites: len(composites) > 0,
+		Composites:    composites,
+	}
+}
+
+// TemplateEntity stores struct info
+type TemplateEntity struct {
+	model.Entity
+
+	// ListName is used in name of function scanning all rows
+	ListName string
+
+	Table      template.HTML
+	ColumnList template.HTML
+
+	Columns []TemplateColumn
+
+	Imports []string
+}
+
+// NewTemplateEntity creates an entity for template
+func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
+	if entity.HasMultiplePKs() {
+		options.KeepPK = true
+	}
+
+	imports := util.NewSet()
+	names := make([]string, len(entity.Columns))
+
+	columns := make([]TemplateColumn, len(entity.Columns))
+	for i, column := range entity.Columns {
+		columns[i] = NewTemplateColumn(entity, column, options)
+		if imp := columns[i].Import; imp != "" {
+			imports.Add(imp)
+		}
+
+		names[i] = quoteIdent(column.PGName)
+	}
+
+	table := quoteIdent(entity.PGName)
+	if entity.PGSchema != util.PublicSchema {
+		table = quoteIdent(entity.PGSchema) + "." + table
+	}
+
+	listName := entity.GoNamePlural
+	if listName == entity.GoName {
+		listName = entity.GoName + "List"
+	}
+
+	return TemplateEntity{
+		Entity: entity,
+
+		ListName: listName,
+
+		Table:      rawString(table),
+		ColumnList: rawString(strings.Join(names, ", ")),
+
+		Columns: columns,
+		Imports: imports.Elements(),
+	}
+}
+
+// TemplateColumn stores column info
+type TemplateColumn struct {
+	model.Column
+
+	// IsCompositeArray is set for arrays of composite types, pgx scans them by composite.Array
+	IsCompositeArray bool
+
+	Tag template.HTML
+}
+
+// NewTemplateColumn creates a column for template
+func NewTemplateColumn(entity model.Entity, column model.Column, options Options) TemplateColumn {
+	column.GoName = options.Overrides.FieldName(entity, column, options.KeepPK)
+
+	override := options.Overrides.Column(entity.PGSchema, entity.PGName, column.PGName)
+	switch {
+	case override.Type != "":
+		column.Composite = nil
+	case column.Composite != nil:
+		column.Type, column.Import = compositeType(*column.Composite, column), ""
+		if column.IsArray {
+			column.Import = compositeImport
+		}
+	default:
+		column.Type, column.Import = GoType(column, options.CustomTypes)
+	}
+
+	tags := util.NewAnnotation()
+	tags.AddTag("db", column.PGName)
+
+	// add json tag
+	if options.AddJSONTag {
+		tags.AddTag("json", util.Underscore(column.PGName))
+	}
+
+	// additional tags from config
+	names := make([]string, 0, len(override.Tags))
+	for name := range override.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tags.AddTag(name, override.Tags[name])
+	}
+
+	return TemplateColumn{
+		Column: column,
+
+		IsCompositeArray: column.Composite != nil && column.IsArray,
+
+		Tag: template.HTML(fmt.Sprintf("`%s`", tags.String())),
+	}
+}
+
+// TemplateComposite stores composite type info
+type TemplateComposite struct {
+	model.Composite
+
+	Fields []TemplateColumn
+}
+
+// NewTemplateComposite creates composite type for template
+func NewTemplateComposite(composite model.Composite, options Options) TemplateComposite {
+	fields := make([]TemplateColumn, len(composite.Fields))
+	for i, field := range composite.Fields {
+		if field.Composite != nil {
+			field.Type, field.Import = compositeType(*field.Composite, field), ""
+		} else {
+			field.Type, field.Import = GoType(field, options.CustomTypes)
+		}
+
+		tags := util.NewAnnotation()
+		tags.AddTag("db", field.PGName)
+
+		fields[i] = TemplateColumn{
+			Column: field,
+			Tag:    template.HTML(fmt.Sprintf("`%s`", tags.String())),
+		}
+	}
+
+	return TemplateComposite{
+		Composite: composite,
+		Fields:    fields,
+	}
+}
+
+// addComposite adds composite type and composite types of its fields if they are not added yet
+func addComposite(composites []TemplateComposite, index, imports *util.Set, composite model.Composite, options Options) []TemplateComposite {
+	if !index.Add(composite.PGFullName) {
+		return composites
+	}
+
+	templateComposite := NewTemplateComposite(composite, options)
+	composites = append(composites, templateComposite)
+	for _, field := range templateComposite.Fields {
+		if field.Import != "" {
+			imports.Add(field.Import)
+		}
+		if field.Composite != nil {
+			composites = addComposite(composites, index, imports, *field.Composite, options)
+		}
+	}
+
+	return composites
+}
+
+// compositeType gets go type of column with composite type, arrays are slices and nullable columns are pointers
+func compositeType(composite model.Composite, column model.Column) string {
+	if column.IsArray {
+		return strings.Repeat("[]", dimensions(column)) + composite.GoName
+	}
+
+	if column.Nullable {
+		return "*" + composite.GoName
+	}
+
+	return composite.GoName
+}
+
+// GoType gets type and import of field scanned by pgx
+// nullable columns use pgtype types, arrays and types which can hold NULL are used as is
+func GoType(column model.Column, customTypes model.CustomTypeMapping) (string, string) {
+	if typ, ok := customTypes.GoType(column.PGType); ok {
+		imp, _ := customTypes.GoImport(column.PGType)
+		if column.IsArray {
+			return strings.Repeat("[]", dimensions(column)) + typ, imp
+		}
+		if column.Nullable {
+			return "*" + typ, imp
+		}
+		return typ, imp
+	}
+
+	if column.IsArray {
+		typ, imp := goType(column.PGType)
+		return strings.Repeat("[]", dimensions(column)) + typ, imp
+	}
+
+	if column.Nullable {
+		return goNullable(column.PGType)
+	}
+
+	return goType(column.PGType)
+}
+
+// goType gets type and import of not null column
+func goType(pgType string) (string, string) {
+	switch pgType {
+	case model.TypePGInt2:
+		return "int16", ""
+	case model.TypePGInt4:
+		return model.TypeInt32, ""
+	case model.TypePGInt8:
+		return model.TypeInt64, ""
+	case model.TypePGFloat4:
+		return model.TypeFloat32, ""
+	case model.TypePGNumeric, model.TypePGFloat8:
+		return model.TypeFloat64, ""
+	case model.TypePGText, model.TypePGVarchar, model.TypePGUuid, model.TypePGBpchar, model.TypePGTimetz:
+		return model.TypeString, ""
+	case model.TypePGBytea:
+		return model.TypeByteSlice, ""
+	case model.TypePGBool:
+		return model.TypeBool, ""
+	case model.TypePGTimestamp, model.TypePGTimestamptz, model.TypePGDate:
+		return model.TypeTime, "time"
+	case model.TypePGTime:
+		return "pgtype.Time", pgtypeImport
+	case model.TypePGInterval:
+		return model.TypeDuration, "time"
+	case model.TypePGJSONB, model.TypePGJSON:
+		return model.TypeMapInterface, ""
+	case model.TypePGHstore:
+		return "pgtype.Hstore", pgtypeImport
+	case model.TypePGInet, model.TypePGCidr:
+		return "netip.Prefix", "net/netip"
+	case model.TypePGPoint:
+		return "pgtype.Point", pgtypeImport
+	}
+
+	return model.TypeInterface, ""
+}
+
+// goNullable gets type and import of nullable column
+func goNullable(pgType string) (string, string) {
+	switch pgType {
+	case model.TypePGInt2:
+		return "pgtype.Int2", pgtypeImport
+	case model.TypePGInt4:
+		return "pgtype.Int4", pgtypeImport
+	case model.TypePGInt8:
+		return "pgtype.Int8", pgtypeImport
+	case model.TypePGFloat4:
+		return "pgtype.Float4", pgtypeImport
+	case model.TypePGFloat8:
+		return "pgtype.Float8", pgtypeImport
+	case model.TypePGNumeric:
+		return "pgtype.Numeric", pgtypeImport
+	case model.TypePGText, model.TypePGVarchar, model.TypePGBpchar, model.TypePGTimetz:
+		return "pgtype.Text", pgtypeImport
+	case model.TypePGUuid:
+		return "pgtype.UUID", pgtypeImport
+	case model.TypePGBool:
+		return "pgtype.Bool", pgtypeImport
+	case model.TypePGTimestamp:
+		return "pgtype.Timestamp", pgtypeImport
+	case model.TypePGTimestamptz:
+		return "pgtype.Timestamptz", pgtypeImport
+	case model.TypePGDate:
+		return "pgtype.Date", pgtypeImport
+	case model.TypePGTime:
+		return "pgtype.Time", pgtypeImport
+	case model.TypePGInterval:
+		return "pgtype.Interval", pgtypeImport
+	case model.TypePGPoint:
+		return "pgtype.Point", pgtypeImport
+	case model.TypePGInet, model.TypePGCidr:
+		return "*netip.Prefix", "net/netip"
+	}
+
+	// bytea, json and hstore are scanned as nil
+	return goType(pgType)
+}
+
+// dimensions gets dimensions of array column
+func dimensions(column model.Column) int {
+	if column.Dimensions == 0 {
+		return 1
+	}
+
+	return column.Dimensions
+}
+
+// quoteIdent quotes postgres identifier
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// rawString gets go string literal, raw one if possible
+func rawString(s string) template.HTML {
+	if strings.Contains(s, "`") {
+		return template.HTML(strconv.Quote(s))
+	}
+
+	return template.HTML("`" + s + "`")
+}
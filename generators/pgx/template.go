@@ -0,0 +1,75 @@
+package pgx
+
+import "github.com/dizzyfool/genna/generators/base"
+
+const Template = templateHeader + templateModels + templateComposites
+
+// MultiTemplate is used for multi-file output: every model with its scanners goes to its own file, composite types go to shared file
+var MultiTemplate = base.MultiTemplate{
+	Name:   "pgx",
+	Shared: templateHeader + templateComposites,
+	Entity: templateHeader + templateModels,
+}
+
+const templateHeader = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}{{if .HasImports}}
+
+import ({{range .Imports}}
+    "{{.}}"{{end}}
+){{end}}
+`
+
+const templateModels = `{{range $model := .Entities}}
+// {{.GoName}}Table is a name of {{.PGFullName}} table
+const {{.GoName}}Table = {{.Table}}
+
+// {{.GoName}}Columns are columns of {{.PGFullName}} table in order used by Scan{{.GoName}}
+const {{.GoName}}Columns = {{.ColumnList}}
+
+type {{.GoName}} struct { {{range .Columns}}
+	{{.GoName}} {{.Type}} {{.Tag}}{{end}}
+}
+
+// Scan{{.GoName}} scans row selected with {{.GoName}}Columns
+func Scan{{.GoName}}(row pgx.Row) ({{.GoName}}, error) {
+	var m {{.GoName}}
+	err := row.Scan({{range .Columns}}
+		{{if .IsCompositeArray}}composite.Array(&m.{{.GoName}}){{else}}&m.{{.GoName}}{{end}},{{end}}
+	)
+
+	return m, err
+}
+
+// Scan{{.ListName}} scans all rows selected with {{.GoName}}Columns and closes them
+func Scan{{.ListName}}(rows pgx.Rows) ([]{{.GoName}}, error) {
+	defer rows.Close()
+
+	var list []{{.GoName}}
+	for rows.Next() {
+		m, err := Scan{{.GoName}}(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+
+	return list, rows.Err()
+}
+{{end}}`
+
+const templateComposites = `{{range .Composites}}
+type {{.GoName}} struct { {{range .Fields}}
+	{{.GoName}} {{.Type}} {{.Tag}}{{end}}
+}
+
+// Scan implements sql.Scanner, composite values are scanned in text format
+func (c *{{.GoName}}) Scan(src interface{}) error {
+	return composite.Scan(src{{range .Fields}}, &c.{{.GoName}}{{end}})
+}
+
+// Value implements driver.Valuer
+func (c {{.GoName}}) Value() (driver.Value, error) {
+	return composite.Value({{range $i, $e := .Fields}}{{if $i}}, {{end}}c.{{.GoName}}{{end}})
+}
+{{end}}`
@@ -0,0 +1,292 @@
+package proto
+
+import (
+	"fmt"
+	"html/template"
+	"path"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+const (
+	timestamppbImport = "google.golang.org/protobuf/types/known/timestamppb"
+	durationpbImport  = "google.golang.org/protobuf/types/known/durationpb"
+	wrapperspbImport  = "google.golang.org/protobuf/types/known/wrapperspb"
+	structpbImport    = "google.golang.org/protobuf/types/known/structpb"
+)
+
+// wrappers are functions of wrapperspb creating wrappers of nullable scalars
+var wrappers = map[string]string{
+	model.TypePGInt2:    "Int32",
+	model.TypePGInt4:    "Int32",
+	model.TypePGInt8:    "Int64",
+	model.TypePGFloat4:  "Float",
+	model.TypePGFloat8:  "Double",
+	model.TypePGNumeric: "Double",
+	model.TypePGBool:    "Bool",
+	model.TypePGText:    "String",
+	model.TypePGVarchar: "String",
+	model.TypePGBpchar:  "String",
+	model.TypePGUuid:    "String",
+	model.TypePGPoint:   "String",
+	model.TypePGInet:    "String",
+}
+
+// scalars are go types of proto scalars generated by protoc-gen-go
+var scalars = map[string]string{
+	model.TypePGInt2:    "int32",
+	model.TypePGInt4:    "int32",
+	model.TypePGInt8:    "int64",
+	model.TypePGFloat4:  "float32",
+	model.TypePGFloat8:  "float64",
+	model.TypePGNumeric: "float64",
+	model.TypePGBool:    "bool",
+	model.TypePGText:    "string",
+	model.TypePGVarchar: "string",
+	model.TypePGBpchar:  "string",
+	model.TypePGUuid:    "string",
+	model.TypePGPoint:   "string",
+}
+
+// ConverterPackage stores converter file info
+type ConverterPackage struct {
+	Package string
+
+	Imports []string
+
+	PBName   string
+	PBImport string
+
+	HasStruct bool
+
+	Entities []ConverterEntity
+	Enums    []ConverterEnum
+}
+
+// NewConverterPackage creates a converter file for template
+func NewConverterPackage(entities []model.Entity, options Options) ConverterPackage {
+	proto := NewTemplatePackage(entities, options)
+	pbImport, pbName := options.GoImport()
+
+	imports := util.NewSet()
+
+	converters := make([]ConverterEntity, len(proto.Entities))
+	for i, entity := range proto.Entities {
+		converters[i] = NewConverterEntity(entity, options)
+
+		for _, field := range converters[i].Fields {
+			for _, imp := range field.Imports {
+				imports.Add(imp)
+			}
+		}
+	}
+
+	enums := make([]ConverterEnum, len(proto.Enums))
+	for i, enum := range proto.Enums {
+		enums[i] = ConverterEnum{
+			TemplateEnum: enum,
+			Var:          util.LowerFirst(enum.GoName),
+		}
+	}
+
+	return ConverterPackage{
+		Package: util.PackageName(path.Base(path.Dir(options.Converter))),
+
+		Imports: imports.Elements(),
+
+		PBName:   pbName,
+		PBImport: pbImport,
+
+		HasStruct: imports.Exists(structpbImport),
+
+		Entities: converters,
+		Enums:    enums,
+	}
+}
+
+// ConverterEntity stores converter functions info
+type ConverterEntity struct {
+	TemplateEntity
+
+	Fields []ConverterField
+}
+
+// NewConverterEntity creates converter functions of message for template
+func NewConverterEntity(entity TemplateEntity, options Options) ConverterEntity {
+	fields := make([]ConverterField, len(entity.Fields))
+	for i, field := range entity.Fields {
+		override := options.Overrides.Column(entity.PGSchema, entity.PGName, field.PGName)
+		fields[i] = NewConverterField(field, options.CustomTypes.Has(field.PGType) || override.Type != "", options)
+	}
+
+	return ConverterEntity{
+		TemplateEntity: entity,
+		Fields:         fields,
+	}
+}
+
+// ConverterField stores statements converting field
+type ConverterField struct {
+	ToProto   template.HTML
+	FromProto template.HTML
+
+	Imports []string
+}
+
+// NewConverterField creates statements converting model field to message field and back
+// custom types can't be converted automatically, comment is generated instead
+func NewConverterField(field TemplateField, custom bool, options Options) ConverterField {
+	m, p := "m."+field.GoName, "p."+field.ProtoGoName
+
+	if custom || field.PGType == model.TypePGCidr {
+		comment := template.HTML(fmt.Sprintf("// %s should be converted manually", field.GoName))
+		return ConverterField{ToProto: comment, FromProto: comment}
+	}
+
+	if field.Enum != nil {
+		return enumField(field, m, p, options)
+	}
+
+	if field.IsArray {
+		if field.PGType == model.TypePGInt2 || field.PGType == model.TypePGInt4 {
+			return ConverterField{
+				ToProto:   loop(m, p, "int32(v)"),
+				FromProto: loop(p, m, "int(v)"),
+			}
+		}
+		return direct(m, p)
+	}
+
+	switch field.PGType {
+	case model.TypePGTimestamp, model.TypePGTimestamptz, model.TypePGDate:
+		return message(field, m, p, timestamppbImport, "timestamppb.New(%s)", "%s.AsTime()")
+	case model.TypePGInterval:
+		return message(field, m, p, durationpbImport, "durationpb.New(%s)", "%s.AsDuration()")
+	case model.TypePGJSON, model.TypePGJSONB:
+		return ConverterField{
+			ToProto:   template.HTML(fmt.Sprintf("%s = toStruct(%s)", p, m)),
+			FromProto: template.HTML(fmt.Sprintf("%s = fromStruct(%s)", m, p)),
+			Imports:   []string{structpbImport},
+		}
+	case model.TypePGHstore, model.TypePGBytea:
+		return direct(m, p)
+	}
+
+	// scalars
+	to, from := "%s", "%s"
+	switch field.PGType {
+	case model.TypePGInt2, model.TypePGInt4:
+		to, from = "int32(%s)", "int(%s)"
+	case model.TypePGInet:
+		to, from = "%s.String()", "net.ParseIP(%s)"
+	}
+
+	// domains are named types in models depending on --domains flag of model generator
+	if options.Domains && isDomain(field.Column) {
+		to, from = scalars[field.PGType]+"(%s)", field.Domain.GoName+"(%s)"
+	}
+
+	// methods of net.IP can be called on pointer
+	value := "*" + m
+	var imports []string
+	if field.PGType == model.TypePGInet {
+		value = m
+		imports = append(imports, "net")
+	}
+
+	if !field.Nullable {
+		return ConverterField{
+			ToProto:   template.HTML(fmt.Sprintf("%s = "+to, p, m)),
+			FromProto: template.HTML(fmt.Sprintf("%s = "+from, m, p)),
+			Imports:   imports,
+		}
+	}
+
+	wrapper := "wrapperspb." + wrappers[field.PGType]
+	return ConverterField{
+		ToProto: template.HTML(fmt.Sprintf("if %s != nil {\n\t\t%s = %s("+to+")\n\t}",
+			m, p, wrapper, value)),
+		FromProto: template.HTML(fmt.Sprintf("if %s != nil {\n\t\tv := "+from+"\n\t\t%s = &v\n\t}",
+			p, p+".Value", m)),
+		Imports: append(imports, wrapperspbImport),
+	}
+}
+
+// ConverterEnum stores maps converting enum values
+type ConverterEnum struct {
+	TemplateEnum
+
+	// Var is prefix of maps names
+	Var string
+}
+
+// enumField creates statements converting enum field using maps of enum values
+// enums are strings or named types in models depending on --enums flag of model generator
+func enumField(field TemplateField, m, p string, options Options) ConverterField {
+	typ, toMap, fromMap := "string", util.LowerFirst(field.Enum.GoName)+"ToProto", util.LowerFirst(field.Enum.GoName)+"FromProto"
+	if options.Enums {
+		typ = field.Enum.GoName
+	}
+
+	if field.Nullable {
+		return ConverterField{
+			ToProto: template.HTML(fmt.Sprintf("if %s != nil {\n\t\t%s = %s[string(*%s)]\n\t}", m, p, toMap, m)),
+			FromProto: template.HTML(fmt.Sprintf("if v, ok := %s[%s]; ok {\n\t\tvalue := %s(v)\n\t\t%s = &value\n\t}",
+				fromMap, p, typ, m)),
+		}
+	}
+
+	return ConverterField{
+		ToProto:   template.HTML(fmt.Sprintf("%s = %s[string(%s)]", p, toMap, m)),
+		FromProto: template.HTML(fmt.Sprintf("%s = %s(%s[%s])", m, typ, fromMap, p)),
+	}
+}
+
+// isDomain checks if column has named type of domain in model, model generator creates them only for domains of basic types
+func isDomain(column model.Column) bool {
+	if column.Domain == nil || column.Enum != nil || column.IsArray {
+		return false
+	}
+
+	switch column.GoType {
+	case model.TypeString, model.TypeBool,
+		model.TypeInt, model.TypeInt32, model.TypeInt64,
+		model.TypeFloat32, model.TypeFloat64:
+		return true
+	}
+
+	return false
+}
+
+// message creates statements converting well-known message field, nil message is NULL
+func message(field TemplateField, m, p, imp, to, from string) ConverterField {
+	if !field.Nullable {
+		return ConverterField{
+			ToProto:   template.HTML(fmt.Sprintf("%s = "+to, p, m)),
+			FromProto: template.HTML(fmt.Sprintf("%s = "+from, m, p)),
+			Imports:   []string{imp},
+		}
+	}
+
+	return ConverterField{
+		ToProto: template.HTML(fmt.Sprintf("if %s != nil {\n\t\t%s = "+to+"\n\t}", m, p, "*"+m)),
+		FromProto: template.HTML(fmt.Sprintf("if %s != nil {\n\t\tv := "+from+"\n\t\t%s = &v\n\t}",
+			p, p, m)),
+		Imports: []string{imp},
+	}
+}
+
+// direct creates statements assigning fields of same type
+func direct(m, p string) ConverterField {
+	return ConverterField{
+		ToProto:   template.HTML(fmt.Sprintf("%s = %s", p, m)),
+		FromProto: template.HTML(fmt.Sprintf("%s = %s", m, p)),
+	}
+}
+
+// loop creates statement converting slice element by element
+func loop(from, to, value string) template.HTML {
+	return template.HTML(fmt.Sprintf("for _, v := range %s {\n\t\t%s = append(%s, %s)\n\t}",
+		from, to, to, value))
+}
@@ -0,0 +1,65 @@
+package proto
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func TestNewConverterField_Domains(t *testing.T) {
+	newField := func(name, pgType string, nullable bool) TemplateField {
+		column := model.NewColumn(name, pgType, "", false, nullable, false, false, 0, false, false, 0, nil, 10, nil)
+		column.AddDomain(model.NewDomain("public", name, nil))
+
+		entity := model.NewEntity("public", "users", []model.Column{column}, nil)
+		return NewTemplateField(entity, column, Options{})
+	}
+
+	tests := []struct {
+		name     string
+		field    TemplateField
+		domains  bool
+		wantTo   template.HTML
+		wantFrom template.HTML
+	}{
+		{
+			name:     "Should assign domain as base type without --domains",
+			field:    newField("email", model.TypePGText, false),
+			wantTo:   "p.Email = m.Email",
+			wantFrom: "m.Email = p.Email",
+		},
+		{
+			name:     "Should convert domain of string",
+			field:    newField("email", model.TypePGText, false),
+			domains:  true,
+			wantTo:   "p.Email = string(m.Email)",
+			wantFrom: "m.Email = Email(p.Email)",
+		},
+		{
+			name:     "Should convert domain of int",
+			field:    newField("rating", model.TypePGInt4, false),
+			domains:  true,
+			wantTo:   "p.Rating = int32(m.Rating)",
+			wantFrom: "m.Rating = Rating(p.Rating)",
+		},
+		{
+			name:     "Should convert nullable domain using wrapper",
+			field:    newField("email", model.TypePGText, true),
+			domains:  true,
+			wantTo:   "if m.Email != nil {\n\t\tp.Email = wrapperspb.String(string(*m.Email))\n\t}",
+			wantFrom: "if p.Email != nil {\n\t\tv := Email(p.Email.Value)\n\t\tm.Email = &v\n\t}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewConverterField(tt.field, false, Options{Domains: tt.domains})
+			if got.ToProto != tt.wantTo {
+				t.Errorf("NewConverterField() ToProto = %q, want %q", got.ToProto, tt.wantTo)
+			}
+			if got.FromProto != tt.wantFrom {
+				t.Errorf("NewConverterField() FromProto = %q, want %q", got.FromProto, tt.wantFrom)
+			}
+		})
+	}
+}
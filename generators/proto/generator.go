@@ -0,0 +1,161 @@
+package proto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	goPackage = "go-package"
+	converter = "converter"
+	keepPK    = "keep-pk"
+	enums     = "enums"
+	domains   = "domains"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("proto", "Protobuf messages generator with optional converters for go-pg models", New())
+}
+
+// Proto represents protobuf generator
+type Proto struct {
+	options Options
+}
+
+// New creates generator
+func New() *Proto {
+	return &Proto{}
+}
+
+// Options gets options
+func (g *Proto) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *Proto) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *Proto) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.String(goPackage, "", "go_package option of proto file, required for converter\nformat: import/path or import/path;name")
+	flags.String(converter, "", "go file for functions converting models to proto messages and back\npackage is last folder name of file path\n")
+
+	flags.BoolP(keepPK, "k", false, "keep primary key name as is (by default it should be converted to 'ID')")
+	flags.Bool(enums, false, "models have named types for enums, set if model generator is used with --enums")
+	flags.Bool(domains, false, "models have named types for domains, set if model generator is used with --domains")
+}
+
+// ReadFlags read flags from command
+func (g *Proto) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if g.options.Options, g.options.Package, err = base.ReadFlags(command); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.GoPackage, err = flags.GetString(goPackage); err != nil {
+		return err
+	}
+
+	if g.options.Converter, err = flags.GetString(converter); err != nil {
+		return err
+	}
+
+	if g.options.KeepPK, err = flags.GetBool(keepPK); err != nil {
+		return err
+	}
+
+	if g.options.Enums, err = flags.GetBool(enums); err != nil {
+		return err
+	}
+
+	if g.options.Domains, err = flags.GetBool(domains); err != nil {
+		return err
+	}
+
+	if g.options.Converter != "" && g.options.GoPackage == "" {
+		return fmt.Errorf("%s is required for converter", goPackage)
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+// proto file and converter are generated from the same entities, so database is read once
+func (g *Proto) Generate() error {
+	if g.options.MultiFile || g.options.SchemaPackage {
+		return fmt.Errorf("multi-file and schema package output are not supported by proto generator")
+	}
+
+	tmpl := Template
+	if g.options.Template != "" {
+		custom, err := base.ReadTemplate(g.options.Template)
+		if err != nil {
+			return err
+		}
+		tmpl = custom
+	}
+
+	generator := base.NewGeneratorFromOptions(g.options.Options)
+
+	entities, err := generator.Read(g.options.Tables, g.options.Views, g.options.FollowFKs, false, g.options.GoPgVer, g.options.CustomTypes)
+	if err != nil {
+		return fmt.Errorf("read database error: %w", err)
+	}
+
+	if err := g.options.Overrides.Check(entities); err != nil {
+		return err
+	}
+
+	if entities, err = g.options.Overrides.Apply(entities); err != nil {
+		return err
+	}
+
+	// checking converter before reporting drift
+	err = generator.GenerateFromEntities(entities, g.options.Output, tmpl, g.Packer())
+	if err != nil && !errors.Is(err, base.ErrDrift) {
+		return err
+	}
+
+	if g.options.Converter == "" {
+		return err
+	}
+
+	if cerr := generator.GenerateFromEntities(entities, g.options.Converter, ConverterTemplate, g.ConverterPacker()); cerr != nil {
+		return cerr
+	}
+
+	return err
+}
+
+// Packer returns packer function for compile entities into proto file
+func (g *Proto) Packer() base.Packer {
+	return func(entities []model.Entity) (interface{}, error) {
+		return NewTemplatePackage(entities, g.options), nil
+	}
+}
+
+// ConverterPacker returns packer function for compile entities into converter file
+func (g *Proto) ConverterPacker() base.Packer {
+	return func(entities []model.Entity) (interface{}, error) {
+		return NewConverterPackage(entities, g.options), nil
+	}
+}
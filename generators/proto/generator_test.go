@@ -0,0 +1,47 @@
+package proto
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"testing"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+
+	generator := New()
+
+	generator.options.Def()
+	generator.options.DDL = path.Join(path.Dir(filename), "..", "..", "test_db.sql")
+	generator.options.Output = path.Join(os.TempDir(), "proto_test.proto")
+	generator.options.Converter = path.Join(os.TempDir(), "model", "proto_test.go")
+	generator.options.Package = "model"
+	generator.options.GoPackage = "github.com/dizzyfool/genna/pb"
+	generator.options.Tables = []string{"public.*", "geo.*", "shop.*"}
+
+	if err := generator.Generate(); err != nil {
+		t.Errorf("generate error = %v", err)
+		return
+	}
+
+	for output, check := range map[string]string{
+		generator.options.Output:    "generator_test.output",
+		generator.options.Converter: "converter_test.output",
+	} {
+		generated, err := ioutil.ReadFile(output)
+		if err != nil {
+			t.Errorf("file not generated = %v", err)
+		}
+
+		expected, err := ioutil.ReadFile(path.Join(path.Dir(filename), check))
+		if err != nil {
+			t.Errorf("check file not found = %v", err)
+		}
+
+		if string(generated) != string(expected) {
+			t.Errorf("generated %s does not match with check", output)
+		}
+	}
+}
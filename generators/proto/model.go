@@ -0,0 +1,341 @@
+package proto
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+const (
+	wktTimestamp = "google/protobuf/timestamp.proto"
+	wktDuration  = "google/protobuf/duration.proto"
+	wktWrappers  = "google/protobuf/wrappers.proto"
+	wktStruct    = "google/protobuf/struct.proto"
+)
+
+// TemplatePackage stores proto file info
+type TemplatePackage struct {
+	Package string
+
+	HasGoPackage bool
+	GoPackage    string
+
+	HasImports bool
+	Imports    []string
+
+	Entities []TemplateEntity
+	Enums    []TemplateEnum
+}
+
+// NewTemplatePackage creates a proto file for template
+func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackage {
+	imports := util.NewSet()
+	enumsIndex := util.NewSet()
+
+	var enums []TemplateEnum
+	models := make([]TemplateEntity, len(entities))
+	for i, entity := range entities {
+		models[i] = NewTemplateEntity(entity, options)
+
+		for _, field := range models[i].Fields {
+			if field.Import != "" {
+				imports.Add(field.Import)
+			}
+
+			if field.Enum != nil && enumsIndex.Add(field.Enum.PGFullName) {
+				enums = append(enums, NewTemplateEnum(*field.Enum))
+			}
+		}
+	}
+
+	// well known types are imported in stable order
+	sorted := imports.Elements()
+	sort.Strings(sorted)
+
+	return TemplatePackage{
+		Package: options.Package,
+
+		HasGoPackage: options.GoPackage != "",
+		GoPackage:    options.GoPackage,
+
+		HasImports: len(sorted) > 0,
+		Imports:    sorted,
+
+		Entities: models,
+		Enums:    enums,
+	}
+}
+
+// TemplateEntity stores message info
+type TemplateEntity struct {
+	model.Entity
+
+	Fields  []TemplateField
+	Skipped []TemplateField
+
+	// Reserved are numbers of dropped and skipped columns, e.g. 2, 5 to 7
+	Reserved string
+}
+
+// NewTemplateEntity creates a message for template
+func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
+	if entity.HasMultiplePKs() {
+		options.KeepPK = true
+	}
+
+	// field numbers are column numbers in table, they are kept when columns are dropped or added
+	// entities read without column numbers are numbered by columns order
+	stable := true
+	for _, column := range entity.Columns {
+		stable = stable && column.Position > 0
+	}
+
+	var fields, skipped []TemplateField
+	used := map[int]bool{}
+	last := 0
+	for _, column := range entity.Columns {
+		if column.Position > last {
+			last = column.Position
+		}
+
+		field := NewTemplateField(entity, column, options)
+		if !field.Supported {
+			skipped = append(skipped, field)
+			continue
+		}
+
+		field.Number = len(fields) + 1
+		if stable {
+			field.Number = column.Position
+		}
+
+		used[field.Number] = true
+		fields = append(fields, field)
+	}
+
+	reserved := ""
+	if stable {
+		reserved = reservedNumbers(used, last)
+	}
+
+	return TemplateEntity{
+		Entity: entity,
+
+		Fields:   fields,
+		Skipped:  skipped,
+		Reserved: reserved,
+	}
+}
+
+// reservedNumbers gets ranges of numbers up to last which are not used by fields, e.g. 2, 5 to 7
+func reservedNumbers(used map[int]bool, last int) string {
+	var ranges []string
+	for start := 1; start <= last; start++ {
+		if used[start] {
+			continue
+		}
+
+		end := start
+		for end+1 <= last && !used[end+1] {
+			end++
+		}
+
+		if end == start {
+			ranges = append(ranges, fmt.Sprint(start))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d to %d", start, end))
+		}
+		start = end
+	}
+
+	return strings.Join(ranges, ", ")
+}
+
+// TemplateField stores message field info
+type TemplateField struct {
+	model.Column
+
+	// Name is proto field name, ProtoGoName is name of field in go code generated by protoc-gen-go
+	Name        string
+	ProtoGoName string
+
+	ProtoType template.HTML
+	Number    int
+
+	// Import is well-known type proto file used by field
+	Import string
+
+	Supported bool
+	Reason    template.HTML
+}
+
+// NewTemplateField creates a message field for template
+func NewTemplateField(entity model.Entity, column model.Column, options Options) TemplateField {
+	column.GoName = options.Overrides.FieldName(entity, column, options.KeepPK)
+
+	name := util.Underscore(column.GoName)
+	field := TemplateField{
+		Column: column,
+
+		Name:        name,
+		ProtoGoName: goCamelCase(name),
+	}
+
+	typ, imp, ok := protoType(column)
+	if !ok {
+		field.Reason = skipReason(column)
+		return field
+	}
+
+	if column.IsArray {
+		typ = "repeated " + typ
+	}
+
+	field.ProtoType = template.HTML(typ)
+	field.Import = imp
+	field.Supported = true
+
+	return field
+}
+
+// protoType gets proto type of column and well-known type proto file to import
+// nullable scalars use wrappers, enums and messages can be omitted anyway
+func protoType(column model.Column) (string, string, bool) {
+	// repeated fields can not be nested or hold maps
+	if column.IsArray && column.Dimensions > 1 {
+		return "", "", false
+	}
+
+	if column.Enum != nil {
+		return column.Enum.GoName, "", true
+	}
+
+	scalar, wrapper := "", ""
+	switch column.PGType {
+	case model.TypePGInt2, model.TypePGInt4:
+		scalar, wrapper = "int32", "Int32Value"
+	case model.TypePGInt8:
+		scalar, wrapper = "int64", "Int64Value"
+	case model.TypePGFloat4:
+		scalar, wrapper = "float", "FloatValue"
+	case model.TypePGFloat8, model.TypePGNumeric:
+		scalar, wrapper = "double", "DoubleValue"
+	case model.TypePGBool:
+		scalar, wrapper = "bool", "BoolValue"
+	case model.TypePGText, model.TypePGVarchar, model.TypePGBpchar, model.TypePGUuid, model.TypePGPoint:
+		scalar, wrapper = "string", "StringValue"
+	case model.TypePGBytea:
+		return "bytes", "", true
+	}
+
+	if column.IsArray {
+		if scalar == "" {
+			return "", "", false
+		}
+		return scalar, "", true
+	}
+
+	switch column.PGType {
+	case model.TypePGInet, model.TypePGCidr:
+		scalar, wrapper = "string", "StringValue"
+	case model.TypePGTimestamp, model.TypePGTimestamptz, model.TypePGDate:
+		return "google.protobuf.Timestamp", wktTimestamp, true
+	case model.TypePGInterval:
+		return "google.protobuf.Duration", wktDuration, true
+	case model.TypePGJSON, model.TypePGJSONB:
+		return "google.protobuf.Struct", wktStruct, true
+	case model.TypePGHstore:
+		return "map<string, string>", "", true
+	}
+
+	if scalar == "" {
+		return "", "", false
+	}
+
+	if column.Nullable {
+		return "google.protobuf." + wrapper, wktWrappers, true
+	}
+
+	return scalar, "", true
+}
+
+// TemplateEnum stores proto enum info
+type TemplateEnum struct {
+	model.Enum
+
+	Values []TemplateEnumValue
+}
+
+// TemplateEnumValue stores proto enum value info
+// zero value is reserved for unspecified value as proto3 requires
+type TemplateEnumValue struct {
+	Name   string
+	Value  string
+	Number int
+}
+
+// NewTemplateEnum creates a proto enum for template
+func NewTemplateEnum(enum model.Enum) TemplateEnum {
+	values := make([]TemplateEnumValue, len(enum.Values))
+	for i, value := range enum.Values {
+		values[i] = TemplateEnumValue{
+			Name:   enumValueName(enum, value),
+			Value:  value,
+			Number: i + 1,
+		}
+	}
+
+	return TemplateEnum{
+		Enum:   enum,
+		Values: values,
+	}
+}
+
+// UnspecifiedName gets name of enum zero value
+func (e TemplateEnum) UnspecifiedName() string {
+	return strings.ToUpper(util.Underscore(e.GoName)) + "_UNSPECIFIED"
+}
+
+// enumValueName gets name of proto enum value, it is prefixed with enum name as enum values share scope
+func enumValueName(enum model.Enum, value string) string {
+	return strings.ToUpper(util.Underscore(enum.ValueGoName(value)))
+}
+
+// goCamelCase converts proto field name to name of go field like protoc-gen-go does
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && util.IsLower(s[i+1]):
+			// skipping underscore, next letter is capitalized
+		case c >= '0' && c <= '9':
+			b = append(b, c)
+		default:
+			if util.IsLower(c) {
+				c = util.ToUpper(c)
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && util.IsLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+
+	return string(b)
+}
+
+// skipReason describes why column has no field in message
+func skipReason(column model.Column) template.HTML {
+	if column.IsArray && column.Dimensions > 1 {
+		return "multidimensional arrays are not supported"
+	}
+
+	return template.HTML(fmt.Sprintf("type %s is not supported", column.PGType))
+}
@@ -0,0 +1,71 @@
+package proto
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func Test_goCamelCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"id", "Id"},
+		{"country_id", "CountryId"},
+		{"api_keys", "ApiKeys"},
+		{"address2_line", "Address2Line"},
+		{"_private", "XPrivate"},
+		{"double__underscore", "Double_Underscore"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goCamelCase(tt.name); got != tt.want {
+				t.Errorf("goCamelCase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplateEntity_Numbers(t *testing.T) {
+	newColumn := func(name, pgType string, position int) model.Column {
+		column := model.NewColumn(name, pgType, "", false, false, false, false, 0, false, false, 0, nil, 10, nil)
+		column.Position = position
+		return column
+	}
+
+	t.Run("Should use column numbers and reserve dropped and skipped columns", func(t *testing.T) {
+		entity := model.NewEntity("public", "users", []model.Column{
+			newColumn("userId", model.TypePGInt4, 1),
+			newColumn("email", model.TypePGText, 3),
+			newColumn("location", "geometry", 4),
+			newColumn("name", model.TypePGText, 7),
+		}, nil)
+
+		tmpl := NewTemplateEntity(entity, Options{})
+
+		var numbers []int
+		for _, field := range tmpl.Fields {
+			numbers = append(numbers, field.Number)
+		}
+		if want := []int{1, 3, 7}; !reflect.DeepEqual(numbers, want) {
+			t.Errorf("numbers = %v, want %v", numbers, want)
+		}
+		if want := "2, 4 to 6"; tmpl.Reserved != want {
+			t.Errorf("Reserved = %v, want %v", tmpl.Reserved, want)
+		}
+	})
+
+	t.Run("Should number by order without column numbers", func(t *testing.T) {
+		entity := model.NewEntity("public", "users", []model.Column{
+			newColumn("userId", model.TypePGInt4, 0),
+			newColumn("email", model.TypePGText, 0),
+		}, nil)
+
+		tmpl := NewTemplateEntity(entity, Options{})
+		if tmpl.Fields[1].Number != 2 || tmpl.Reserved != "" {
+			t.Errorf("Number = %v, Reserved = %v", tmpl.Fields[1].Number, tmpl.Reserved)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package proto
+
+import (
+	"path"
+	"strings"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/util"
+)
+
+// Options for generator
+type Options struct {
+	base.Options
+
+	// Package sets package name for proto file
+	Package string
+
+	// GoPackage sets go_package option of proto file, required for converter
+	// format: import/path or import/path;name
+	GoPackage string
+
+	// Converter is go file with functions converting models to proto messages and back
+	Converter string
+
+	// Do not replace primary key name to ID
+	KeepPK bool
+
+	// Models have named types for enums
+	Enums bool
+
+	// Models have named types for domains
+	Domains bool
+}
+
+// Def fills default values of an options
+func (o *Options) Def() {
+	o.Options.Def()
+
+	if strings.Trim(o.Package, " ") == "" {
+		o.Package = util.DefaultPackage
+	}
+}
+
+// GoImport gets import path and name of go package generated from proto file
+func (o Options) GoImport() (string, string) {
+	importPath, name := o.GoPackage, ""
+	if i := strings.Index(o.GoPackage, ";"); i >= 0 {
+		importPath, name = o.GoPackage[:i], o.GoPackage[i+1:]
+	}
+
+	if name == "" {
+		name = util.PackageName(path.Base(importPath))
+	}
+
+	return importPath, name
+}
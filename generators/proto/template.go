@@ -0,0 +1,87 @@
+package proto
+
+// Template is used for proto file
+const Template = `// Code generated by genna. DO NOT EDIT.
+
+syntax = "proto3";
+
+package {{.Package}};{{if .HasGoPackage}}
+
+option go_package = "{{.GoPackage}}";{{end}}{{if .HasImports}}
+{{range .Imports}}
+import "{{.}}";{{end}}{{end}}
+{{range $enum := .Enums}}
+enum {{.GoName}} {
+  {{.UnspecifiedName}} = 0;{{range .Values}}
+  {{.Name}} = {{.Number}};{{end}}
+}
+{{end}}{{range .Entities}}
+message {{.GoName}} {{"{"}}{{range .Fields}}
+  {{.ProtoType}} {{.Name}} = {{.Number}};{{end}}{{range .Skipped}}
+  // {{.PGName}} is skipped: {{.Reason}}{{end}}{{if .Reserved}}
+  reserved {{.Reserved}};{{end}}
+}
+{{end}}`
+
+// ConverterTemplate is used for go file converting models to proto messages
+const ConverterTemplate = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import ({{range .Imports}}
+	"{{.}}"{{end}}
+
+	{{.PBName}} "{{.PBImport}}"
+)
+{{range $enum := .Enums}}
+var {{.Var}}ToProto = map[string]{{$.PBName}}.{{.GoName}}{ {{range .Values}}
+	{{quote .Value}}: {{$.PBName}}.{{$enum.GoName}}_{{.Name}},{{end}}
+}
+
+var {{.Var}}FromProto = map[{{$.PBName}}.{{.GoName}}]string{ {{range .Values}}
+	{{$.PBName}}.{{$enum.GoName}}_{{.Name}}: {{quote .Value}},{{end}}
+}
+{{end}}{{range .Entities}}
+// {{.GoName}}ToProto converts {{.GoName}} model to proto message
+func {{.GoName}}ToProto(m *{{.GoName}}) *{{$.PBName}}.{{.GoName}} {
+	if m == nil {
+		return nil
+	}
+
+	p := &{{$.PBName}}.{{.GoName}}{}{{range .Fields}}
+	{{.ToProto}}{{end}}
+
+	return p
+}
+
+// {{.GoName}}FromProto converts proto message to {{.GoName}} model
+func {{.GoName}}FromProto(p *{{$.PBName}}.{{.GoName}}) *{{.GoName}} {
+	if p == nil {
+		return nil
+	}
+
+	m := &{{.GoName}}{}{{range .Fields}}
+	{{.FromProto}}{{end}}
+
+	return m
+}
+{{end}}{{if .HasStruct}}
+// toStruct converts json column to proto struct, values decoded from json are always supported
+func toStruct(m map[string]interface{}) *structpb.Struct {
+	if m == nil {
+		return nil
+	}
+
+	s, _ := structpb.NewStruct(m)
+	return s
+}
+
+// fromStruct converts proto struct to json column
+func fromStruct(s *structpb.Struct) map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+
+	return s.AsMap()
+}
+{{end}}`
@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	keepPK     = "keep-pk"
+	softDelete = "soft-delete"
+	withSearch = "search"
+)
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("repo", "CRUD repository generator for go-pg models", New())
+}
+
+// Repo represents repository generator
+type Repo struct {
+	options Options
+}
+
+// New creates generator
+func New() *Repo {
+	return &Repo{}
+}
+
+// Options gets options
+func (g *Repo) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *Repo) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *Repo) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.BoolP(keepPK, "k", false, "keep primary key name as is (by default it should be converted to 'ID')")
+	flags.StringP(softDelete, "s", "", "soft delete column, rows are marked as deleted instead of removing\n")
+
+	flags.Bool(withSearch, false, "filter lists with search structs generated by search generator")
+}
+
+// ReadFlags read flags from command
+func (g *Repo) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if g.options.Options, g.options.Package, err = base.ReadFlags(command); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.KeepPK, err = flags.GetBool(keepPK); err != nil {
+		return err
+	}
+
+	if g.options.SoftDelete, err = flags.GetString(softDelete); err != nil {
+		return err
+	}
+
+	if g.options.WithSearch, err = flags.GetBool(withSearch); err != nil {
+		return err
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *Repo) Generate() error {
+	if g.options.Target != base.TargetGoPG {
+		return fmt.Errorf("target %s is not supported by repo generator", g.options.Target)
+	}
+
+	generator := base.NewGeneratorFromOptions(g.options.Options)
+	if g.options.MultiFile {
+		return generator.GenerateMulti(g.options.Options, false, MultiTemplate, g.Packer())
+	}
+
+	return generator.Generate(
+		g.options.Options,
+		false,
+		Template,
+		g.Packer(),
+	)
+}
+
+// Packer returns packer function for compile entities into package
+func (g *Repo) Packer() base.Packer {
+	return func(entities []model.Entity) (interface{}, error) {
+		return NewTemplatePackage(entities, g.options), nil
+	}
+}
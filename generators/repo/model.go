@@ -0,0 +1,229 @@
+package repo
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+// reserved are names used in generated methods, params can't be named so
+var reserved = map[string]struct{}{
+	"ctx": {}, "m": {}, "r": {}, "res": {}, "err": {}, "query": {}, "list": {}, "search": {}, "pager": {},
+}
+
+// TemplatePackage stores package info
+type TemplatePackage struct {
+	Package string
+
+	HasImports bool
+	Imports    []string
+
+	GoPGVer string
+	Ident   string
+
+	Entities []TemplateEntity
+}
+
+// NewTemplatePackage creates a package for template
+func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackage {
+	imports := util.NewSet()
+	imports.Add("context")
+
+	models := make([]TemplateEntity, len(entities))
+	for i, entity := range entities {
+		models[i] = NewTemplateEntity(entity, options)
+
+		for _, imp := range models[i].Imports {
+			imports.Add(imp)
+		}
+	}
+
+	goPGVer, ident := "", "pg.F"
+	if options.GoPgVer >= 9 {
+		goPGVer, ident = fmt.Sprintf("/v%d", options.GoPgVer), "pg.Ident"
+	}
+
+	return TemplatePackage{
+		Package: base.PackageName(options.Options, options.Package, entities),
+
+		HasImports: imports.Len() > 0,
+		Imports:    imports.Elements(),
+
+		GoPGVer: goPGVer,
+		Ident:   ident,
+
+		Entities: models,
+	}
+}
+
+// TemplateEntity stores struct info
+type TemplateEntity struct {
+	model.Entity
+
+	ReadOnly bool
+
+	HasPK bool
+	PKs   []TemplatePK
+
+	// Uniques are lookups by unique keys
+	Uniques []TemplateUnique
+
+	HasSoftDelete bool
+	SoftDelete    string
+
+	HasSearch bool
+
+	Imports []string
+}
+
+// NewTemplateEntity creates an entity for template
+func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
+	if entity.HasMultiplePKs() {
+		options.KeepPK = true
+	}
+
+	imports := util.NewSet()
+
+	softDelete := options.SoftDelete
+	if table := options.Overrides.Table(entity.PGSchema, entity.PGName); table.SoftDelete != "" {
+		softDelete = table.SoftDelete
+	}
+
+	var pks []TemplatePK
+	hasSoftDelete, softDeleteName, hasSearch := false, "", false
+	for _, column := range entity.Columns {
+		if column.IsPK {
+			pks = append(pks, NewTemplatePK(entity, column, options))
+			if column.Import != "" {
+				imports.Add(column.Import)
+			}
+		}
+
+		if column.PGName == softDelete && column.Nullable && column.GoType == model.TypeTime && !column.IsArray {
+			hasSoftDelete, softDeleteName = true, column.GoName
+		}
+
+		// same columns are used by search generator, entities without them have no search struct
+		if !column.IsArray && column.GoType != model.TypeMapInterface && column.GoType != model.TypeMapString {
+			hasSearch = options.WithSearch
+		}
+	}
+
+	var uniques []TemplateUnique
+	names := util.NewSet()
+	names.Add("GetByID")
+	for _, index := range entity.UniqueKeys() {
+		unique, ok := NewTemplateUnique(entity, index, options)
+		if !ok || !names.Add(unique.Name) {
+			continue
+		}
+
+		uniques = append(uniques, unique)
+		for _, column := range unique.Columns {
+			if column.Import != "" {
+				imports.Add(column.Import)
+			}
+		}
+	}
+
+	return TemplateEntity{
+		Entity: entity,
+
+		ReadOnly: entity.ReadOnly(),
+
+		HasPK: len(pks) > 0,
+		PKs:   pks,
+
+		Uniques: uniques,
+
+		HasSoftDelete: hasSoftDelete,
+		SoftDelete:    softDeleteName,
+
+		HasSearch: hasSearch,
+
+		Imports: imports.Elements(),
+	}
+}
+
+// TemplatePK stores primary key column info
+type TemplatePK struct {
+	model.Column
+
+	// Param is a name of method param holding primary key value
+	Param string
+}
+
+// NewTemplatePK creates a primary key column for template
+func NewTemplatePK(entity model.Entity, column model.Column, options Options) TemplatePK {
+	column.GoName = options.Overrides.FieldName(entity, column, options.KeepPK)
+
+	return newTemplateParam(column)
+}
+
+// newTemplateParam creates a column used as method param
+func newTemplateParam(column model.Column) TemplatePK {
+	param := util.LowerFirst(column.GoName)
+	if column.GoName == util.ID {
+		param = "id"
+	}
+
+	if _, ok := reserved[param]; ok || token.IsKeyword(param) {
+		param = "pk" + column.GoName
+	}
+
+	return TemplatePK{
+		Column: column,
+		Param:  param,
+	}
+}
+
+// TemplateUnique stores unique key info
+type TemplateUnique struct {
+	// Name is a name of lookup method, e.g. GetByEmail
+	Name       string
+	Constraint string
+
+	Columns []TemplatePK
+}
+
+// NewTemplateUnique creates a unique key lookup for template, keys with columns not comparable in go are skipped
+func NewTemplateUnique(entity model.Entity, index model.Index, options Options) (TemplateUnique, bool) {
+	columns := make([]TemplatePK, len(index.Columns))
+	goNames := make([]string, len(index.Columns))
+	for i, name := range index.Columns {
+		column, ok := findColumn(entity, name)
+		if !ok || column.IsArray || column.GoType == model.TypeMapInterface || column.GoType == model.TypeMapString || column.GoType == model.TypeInterface {
+			return TemplateUnique{}, false
+		}
+
+		// nil can't be found by equality
+		column.Type = strings.TrimPrefix(column.Type, "*")
+
+		if column.IsPK {
+			columns[i] = NewTemplatePK(entity, column, options)
+		} else {
+			columns[i] = newTemplateParam(column)
+		}
+		goNames[i] = columns[i].GoName
+	}
+
+	return TemplateUnique{
+		Name:       "GetBy" + strings.Join(goNames, "And"),
+		Constraint: index.Name,
+		Columns:    columns,
+	}, true
+}
+
+func findColumn(entity model.Entity, name string) (model.Column, bool) {
+	for _, column := range entity.Columns {
+		if column.PGName == name {
+			return column, true
+		}
+	}
+
+	return model.Column{}, false
+}
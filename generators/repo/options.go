@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"strings"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/util"
+)
+
+// Options for generator
+type Options struct {
+	base.Options
+
+	// Package sets package name for model
+	// Not used with SchemaPackage, every package is named after its schema
+	Package string
+
+	// Do not replace primary key name to ID
+	KeepPK bool
+
+	// Soft delete column
+	SoftDelete string
+
+	// Use search structs from search generator in lists
+	WithSearch bool
+}
+
+// Def fills default values of an options
+func (o *Options) Def() {
+	o.Options.Def()
+
+	if strings.Trim(o.Package, " ") == "" {
+		o.Package = util.DefaultPackage
+	}
+}
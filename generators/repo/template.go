@@ -0,0 +1,142 @@
+package repo
+
+import "github.com/dizzyfool/genna/generators/base"
+
+const Template = templateHeader + templatePager + templateRepos
+
+// MultiTemplate is used for multi-file output: pager goes to shared file, repositories to their own files
+var MultiTemplate = base.MultiTemplate{
+	Name:   "repo",
+	Shared: templateHeader + templatePager,
+	Entity: templateHeader + templateRepos,
+}
+
+const templateHeader = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import ({{if .HasImports}}{{range .Imports}}
+	"{{.}}"{{end}}
+	{{end}}
+	"github.com/go-pg/pg{{.GoPGVer}}"
+	"github.com/go-pg/pg{{.GoPGVer}}/orm"
+)
+`
+
+const templatePager = `
+// Pager limits lists, zero Limit means no limit
+type Pager struct {
+	Limit  int
+	Offset int
+}
+
+func (p Pager) apply(query *orm.Query) *orm.Query {
+	if p.Limit > 0 {
+		query.Limit(p.Limit)
+	}
+
+	if p.Offset > 0 {
+		query.Offset(p.Offset)
+	}
+
+	return query
+}
+`
+
+const templateRepos = `{{range $model := .Entities}}
+// {{.GoName}}Repo is a repository of {{.GoName}} model
+type {{.GoName}}Repo struct {
+	db orm.DB
+}
+
+// New{{.GoName}}Repo creates repository of {{.GoName}} model
+func New{{.GoName}}Repo(db orm.DB) {{.GoName}}Repo {
+	return {{.GoName}}Repo{db: db}
+}
+{{if .HasPK}}
+// GetByID gets {{.GoName}} by primary key, returns nil if it is not found
+func (r {{.GoName}}Repo) GetByID(ctx context.Context, {{range $i, $e := .PKs}}{{if $i}}, {{end}}{{.Param}} {{.Type}}{{end}}) (*{{.GoName}}, error) {
+	m := &{{.GoName}}{ {{range $i, $e := .PKs}}{{if $i}}, {{end}}{{.GoName}}: {{.Param}}{{end}} }
+
+	query := r.db.ModelContext(ctx, m).WherePK(){{if .HasSoftDelete}}.
+		Where("?TableAlias.? IS NULL", {{$.Ident}}(Columns.{{.GoName}}.{{.SoftDelete}})){{end}}
+
+	if err := query.Select(); err == pg.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+{{end}}{{range .Uniques}}
+// {{.Name}} gets {{$model.GoName}} by unique key {{.Constraint}}, returns nil if it is not found
+func (r {{$model.GoName}}Repo) {{.Name}}(ctx context.Context, {{range $i, $e := .Columns}}{{if $i}}, {{end}}{{.Param}} {{.Type}}{{end}}) (*{{$model.GoName}}, error) {
+	m := &{{$model.GoName}}{}
+
+	query := r.db.ModelContext(ctx, m){{range .Columns}}.
+		Where("?TableAlias.? = ?", {{$.Ident}}(Columns.{{$model.GoName}}.{{.GoName}}), {{.Param}}){{end}}{{if $model.HasSoftDelete}}.
+		Where("?TableAlias.? IS NULL", {{$.Ident}}(Columns.{{$model.GoName}}.{{$model.SoftDelete}})){{end}}
+
+	if err := query.Select(); err == pg.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+{{end}}
+// List gets {{.GoNamePlural}}{{if .HasSearch}} filtered by search{{end}}
+func (r {{.GoName}}Repo) List(ctx context.Context, {{if .HasSearch}}search *{{.GoName}}Search, {{end}}pager Pager) ([]{{.GoName}}, error) {
+	var list []{{.GoName}}
+
+	query := r.db.ModelContext(ctx, &list){{if .HasSoftDelete}}.
+		Where("?TableAlias.? IS NULL", {{$.Ident}}(Columns.{{.GoName}}.{{.SoftDelete}})){{end}}{{if .HasSearch}}
+	if search != nil {
+		search.Apply(query)
+	}{{end}}{{range .PKs}}
+	query.OrderExpr("?TableAlias.?", {{$.Ident}}(Columns.{{$model.GoName}}.{{.GoName}})){{end}}
+
+	if err := pager.apply(query).Select(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+{{if not .ReadOnly}}
+// Insert inserts {{.GoName}}, values set by database are written back to m
+func (r {{.GoName}}Repo) Insert(ctx context.Context, m *{{.GoName}}) error {
+	_, err := r.db.ModelContext(ctx, m).Returning("*").Insert()
+	return err
+}
+{{if .HasPK}}
+// Update updates {{.GoName}} by primary key, returns false if it is not found
+func (r {{.GoName}}Repo) Update(ctx context.Context, m *{{.GoName}}) (bool, error) {
+	query := r.db.ModelContext(ctx, m).WherePK(){{if .HasSoftDelete}}.
+		Where("?TableAlias.? IS NULL", {{$.Ident}}(Columns.{{.GoName}}.{{.SoftDelete}})){{end}}
+
+	res, err := query.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// Delete {{if .HasSoftDelete}}marks {{.GoName}} as deleted{{else}}deletes {{.GoName}}{{end}} by primary key, returns false if it is not found
+func (r {{.GoName}}Repo) Delete(ctx context.Context, {{range $i, $e := .PKs}}{{if $i}}, {{end}}{{.Param}} {{.Type}}{{end}}) (bool, error) {
+	m := &{{.GoName}}{ {{range $i, $e := .PKs}}{{if $i}}, {{end}}{{.GoName}}: {{.Param}}{{end}} }
+{{if .HasSoftDelete}}
+	res, err := r.db.ModelContext(ctx, m).WherePK().
+		Where("?TableAlias.? IS NULL", {{$.Ident}}(Columns.{{.GoName}}.{{.SoftDelete}})).
+		Set("? = now()", {{$.Ident}}(Columns.{{.GoName}}.{{.SoftDelete}})).
+		Update(){{else}}
+	res, err := r.db.ModelContext(ctx, m).WherePK().Delete(){{end}}
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+{{end}}{{end}}{{end}}`
@@ -58,8 +58,7 @@ func (g *Search) AddFlags(command *cobra.Command) {
 func (g *Search) ReadFlags(command *cobra.Command) error {
 	var err error
 
-	g.options.URL, g.options.Output, g.options.Package, g.options.Tables, g.options.FollowFKs, g.options.GoPgVer, g.options.CustomTypes, err = base.ReadFlags(command)
-	if err != nil {
+	if g.options.Options, g.options.Package, err = base.ReadFlags(command); err != nil {
 		return err
 	}
 
@@ -89,32 +88,27 @@ func (g *Search) ReadFlags(command *cobra.Command) error {
 
 // Generate runs whole generation process
 func (g *Search) Generate() error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			false,
-			g.options.Output,
-			Template,
-			g.Packer(),
-			g.options.GoPgVer,
-			g.options.CustomTypes,
-		)
+	return g.Repack(g.Packer())
 }
 
 // Repack runs generator with custom packer
 func (g *Search) Repack(packer base.Packer) error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			false,
-			g.options.Output,
-			Template,
-			packer,
-			g.options.GoPgVer,
-			g.options.CustomTypes,
-		)
+	tmpl, multiTmpl := Template, MultiTemplate
+	if g.options.Target == base.TargetBun {
+		tmpl, multiTmpl = BunTemplate, BunMultiTemplate
+	}
+
+	generator := base.NewGeneratorFromOptions(g.options.Options)
+	if g.options.MultiFile {
+		return generator.GenerateMulti(g.options.Options, false, multiTmpl, packer)
+	}
+
+	return generator.Generate(
+		g.options.Options,
+		false,
+		tmpl,
+		packer,
+	)
 }
 
 // Packer returns packer function for compile entities into package
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"html/template"
 
+	"github.com/dizzyfool/genna/generators/base"
 	"github.com/dizzyfool/genna/model"
 	"github.com/dizzyfool/genna/util"
 )
@@ -51,7 +52,7 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 	}
 
 	return TemplatePackage{
-		Package: options.Package,
+		Package: base.PackageName(options.Options, options.Package, entities),
 
 		HasImports: imports.Len() > 0,
 		Imports:    imports.Elements(),
@@ -66,6 +67,8 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 type TemplateEntity struct {
 	model.Entity
 
+	Doc template.HTML
+
 	NoAlias bool
 	Alias   string
 
@@ -97,6 +100,8 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 	return TemplateEntity{
 		Entity: entity,
 
+		Doc: base.DocComment(entity.Comment),
+
 		NoAlias: options.NoAlias,
 		Alias:   util.DefaultAlias,
 
@@ -111,6 +116,8 @@ type TemplateColumn struct {
 
 	Relaxed bool
 
+	Doc template.HTML
+
 	HasTags bool
 	Tag     template.HTML
 
@@ -119,10 +126,8 @@ type TemplateColumn struct {
 }
 
 // NewTemplateColumn creates a column for template
-func NewTemplateColumn(_ model.Entity, column model.Column, options Options) TemplateColumn {
-	if !options.KeepPK && column.IsPK {
-		column.GoName = util.ID
-	}
+func NewTemplateColumn(entity model.Entity, column model.Column, options Options) TemplateColumn {
+	column.GoName = options.Overrides.FieldName(entity, column, options.KeepPK)
 
 	if options.Relaxed {
 		column.Type = model.TypeInterface
@@ -139,6 +144,7 @@ func NewTemplateColumn(_ model.Entity, column model.Column, options Options) Tem
 	return TemplateColumn{
 		Relaxed: options.Relaxed,
 		Column:  column,
+		Doc:     base.DocComment(column.Comment),
 		HasTags: tags.Len() > 0,
 		Tag:     template.HTML(fmt.Sprintf("`%s`", tags.String())),
 	}
@@ -1,6 +1,27 @@
 package search
 
-const Template = `//nolint
+import "github.com/dizzyfool/genna/generators/base"
+
+const Template = templateHeader + templateSearch + templateModels
+
+// MultiTemplate is used for multi-file output: common code goes to shared file, search code of models to their own files
+var MultiTemplate = base.MultiTemplate{
+	Name:   "search",
+	Shared: templateHeader + templateSearch,
+	Entity: templateHeader + templateModels,
+}
+
+// BunTemplate is used for bun target
+const BunTemplate = templateBunHeader + templateBunSearch + templateBunModels
+
+// BunMultiTemplate is used for multi-file output for bun target
+var BunMultiTemplate = base.MultiTemplate{
+	Name:   "search",
+	Shared: templateBunHeader + templateBunSearch,
+	Entity: templateBunHeader + templateBunModels,
+}
+
+const templateHeader = `//nolint
 //lint:file-ignore U1000 ignore unused code, it's generated
 package {{.Package}}
 
@@ -10,7 +31,9 @@ import ({{if .HasImports}}{{range .Imports}}
 	"github.com/go-pg/pg{{.GoPGVer}}"
 	"github.com/go-pg/pg{{.GoPGVer}}/orm"
 )
+`
 
+const templateSearch = `
 const condition =  "?.? = ?"
 
 // base filters
@@ -56,12 +79,14 @@ type Searcher interface {
 	WithApply(a applier)
 }
 
-{{range $model := .Entities}}
-type {{.GoName}}Search struct {
+`
+
+const templateModels = `{{range $model := .Entities}}
+{{.Doc}}type {{.GoName}}Search struct {
 	search 
 
 	{{range .Columns}}
-	{{.GoName}} {{.Type}}{{if .HasTags}} {{.Tag}}{{end}}{{end}}
+	{{.Doc}}{{.GoName}} {{.Type}}{{if .HasTags}} {{.Tag}}{{end}}{{end}}
 }
 
 func (s *{{.GoName}}Search) Apply(query *orm.Query) *orm.Query { {{range .Columns}}{{if .Relaxed}}
@@ -83,3 +108,86 @@ func (s *{{.GoName}}Search) Q() applier {
 }
 {{end}}
 `
+
+const templateBunHeader = `//nolint
+//lint:file-ignore U1000 ignore unused code, it's generated
+package {{.Package}}
+
+import ({{if .HasImports}}{{range .Imports}}
+	"{{.}}"{{end}}
+	{{end}}
+	"github.com/uptrace/bun"
+)
+`
+
+const templateBunSearch = `
+const condition =  "?.? = ?"
+
+// base filters
+type applier func(query *bun.SelectQuery) *bun.SelectQuery
+
+type search struct {
+	appliers[] applier
+}
+
+func (s *search) apply(query *bun.SelectQuery) {
+	for _, applier := range s.appliers {
+		query.Apply(applier)
+	}
+}
+
+func (s *search) where(query *bun.SelectQuery, table, field string, value interface{}) {
+	query.Where(condition, bun.Ident(table), bun.Ident(field), value)
+}
+
+func (s *search) WithApply(a applier) {
+	if s.appliers == nil {
+		s.appliers = []applier{}
+	}
+	s.appliers = append(s.appliers, a)
+}
+
+func (s *search) With(condition string, params ...interface{}) {
+	s.WithApply(func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.Where(condition, params...)
+	})
+}
+
+// Searcher is interface for every generated filter
+type Searcher interface {
+	Apply(query *bun.SelectQuery) *bun.SelectQuery
+	Q() applier
+
+	With(condition string, params ...interface{})
+	WithApply(a applier)
+}
+
+`
+
+const templateBunModels = `{{range $model := .Entities}}
+{{.Doc}}type {{.GoName}}Search struct {
+	search 
+
+	{{range .Columns}}
+	{{.Doc}}{{.GoName}} {{.Type}}{{if .HasTags}} {{.Tag}}{{end}}{{end}}
+}
+
+func (s *{{.GoName}}Search) Apply(query *bun.SelectQuery) *bun.SelectQuery { {{range .Columns}}{{if .Relaxed}}
+	if !reflect.ValueOf(s.{{.GoName}}).IsNil(){ {{else}}
+	if s.{{.GoName}} != nil { {{end}}{{if .UseCustomRender}}
+		{{.CustomRender}}{{else}} 
+		s.where(query, Tables.{{$model.GoName}}.{{if not $model.NoAlias}}Alias{{else}}Name{{end}}, Columns.{{$model.GoName}}.{{.GoName}}, s.{{.GoName}}){{end}}
+	}{{end}}
+
+	s.apply(query)
+	
+	return query
+}
+
+func (s *{{.GoName}}Search) Q() applier {
+	return func(query *bun.SelectQuery) *bun.SelectQuery {
+		return s.Apply(query)
+	}
+}
+{{end}}
+`
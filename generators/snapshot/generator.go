@@ -0,0 +1,144 @@
+package snapshot
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/lib"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateCommand creates snapshot command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("snapshot", "Saves database schema to json snapshot file", New())
+}
+
+// Snapshot represents snapshot command
+type Snapshot struct {
+	options base.Options
+}
+
+// New creates snapshot command
+func New() *Snapshot {
+	return &Snapshot{}
+}
+
+// Options gets options
+func (g *Snapshot) Options() *base.Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *Snapshot) SetOptions(options base.Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *Snapshot) AddFlags(command *cobra.Command) {
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.String(base.ConfigFile, "", "yaml config file, "+base.DefaultConfig+" from working directory is used if exists\nflags override config values")
+	flags.StringP(base.Conn, "c", "", "connection string to your postgres database")
+	flags.String(base.DDL, "", "sql file with schema DDL to read instead of database")
+
+	flags.StringP(base.Output, "o", "", "output file name, required")
+
+	flags.Bool(base.Check, false, "compare snapshot with existing file instead of writing\nprints diff and exits with non-zero code if they differ")
+
+	flags.StringSliceP(base.Tables, "t", []string{"public.*"}, "table names for snapshot separated by comma\nuse 'schema_name.*' to save every table in schema")
+	flags.BoolP(base.FollowFKs, "f", false, "save foreign keys tables, even if it not listed in Tables\n")
+	flags.Bool(base.Views, false, "save views and materialized views\n")
+}
+
+// ReadFlags read flags from command
+func (g *Snapshot) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	// overrides are not used, snapshot stores schema as is
+	if _, err = base.ApplyConfig(command); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.URL, err = flags.GetString(base.Conn); err != nil {
+		return err
+	}
+
+	if g.options.DDL, err = flags.GetString(base.DDL); err != nil {
+		return err
+	}
+
+	if g.options.URL == "" && g.options.DDL == "" {
+		return fmt.Errorf("connection string or DDL file is required")
+	}
+
+	if g.options.Output, err = flags.GetString(base.Output); err != nil {
+		return err
+	}
+
+	if g.options.Output == "" {
+		return fmt.Errorf("output file is required")
+	}
+
+	if g.options.Check, err = flags.GetBool(base.Check); err != nil {
+		return err
+	}
+
+	if g.options.Tables, err = flags.GetStringSlice(base.Tables); err != nil {
+		return err
+	}
+
+	if g.options.FollowFKs, err = flags.GetBool(base.FollowFKs); err != nil {
+		return err
+	}
+
+	if g.options.Views, err = flags.GetBool(base.Views); err != nil {
+		return err
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate reads database and saves snapshot
+func (g *Snapshot) Generate() error {
+	generator := base.NewGeneratorFromOptions(g.options)
+
+	entities, err := generator.Read(g.options.Tables, g.options.Views, g.options.FollowFKs, false, g.options.GoPgVer, g.options.CustomTypes)
+	if err != nil {
+		return fmt.Errorf("read database error: %w", err)
+	}
+
+	snapshot := genna.NewSnapshot(entities)
+	if g.options.Check {
+		content, err := snapshot.Marshal()
+		if err != nil {
+			return fmt.Errorf("encoding snapshot error: %w", err)
+		}
+
+		drift, err := base.CheckFile(content, g.options.Output)
+		if err != nil {
+			return err
+		}
+		if drift {
+			return base.ErrDrift
+		}
+
+		log.Printf("snapshot of %d entities is up to date", len(entities))
+		return nil
+	}
+
+	if err := snapshot.Save(g.options.Output); err != nil {
+		return fmt.Errorf("saving snapshot error: %w", err)
+	}
+
+	log.Printf("successfully saved %d entities", len(entities))
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package typescript
+
+import (
+	"fmt"
+
+	"github.com/dizzyfool/genna/generators/base"
+	"github.com/dizzyfool/genna/model"
+
+	"github.com/spf13/cobra"
+)
+
+const hasMany = "has-many"
+
+// CreateCommand creates generator command
+func CreateCommand() *cobra.Command {
+	return base.CreateCommand("typescript", "TypeScript interfaces generator for json of models", New())
+}
+
+// TypeScript represents typescript generator
+type TypeScript struct {
+	options Options
+}
+
+// New creates generator
+func New() *TypeScript {
+	return &TypeScript{}
+}
+
+// Options gets options
+func (g *TypeScript) Options() *Options {
+	return &g.options
+}
+
+// SetOptions sets options
+func (g *TypeScript) SetOptions(options Options) {
+	g.options = options
+}
+
+// AddFlags adds flags to command
+func (g *TypeScript) AddFlags(command *cobra.Command) {
+	base.AddFlags(command)
+
+	flags := command.Flags()
+	flags.SortFlags = false
+
+	flags.Bool(hasMany, false, "add has-many and many2many relations, set if model generator is used with --has-many")
+}
+
+// ReadFlags read flags from command
+func (g *TypeScript) ReadFlags(command *cobra.Command) error {
+	var err error
+
+	if g.options.Options, _, err = base.ReadFlags(command); err != nil {
+		return err
+	}
+
+	flags := command.Flags()
+
+	if g.options.HasMany, err = flags.GetBool(hasMany); err != nil {
+		return err
+	}
+
+	// setting defaults
+	g.options.Def()
+
+	return nil
+}
+
+// Generate runs whole generation process
+func (g *TypeScript) Generate() error {
+	if g.options.MultiFile || g.options.SchemaPackage {
+		return fmt.Errorf("multi-file and schema package output are not supported by typescript generator")
+	}
+
+	return base.NewGeneratorFromOptions(g.options.Options).Generate(
+		g.options.Options,
+		false,
+		Template,
+		g.Packer(),
+	)
+}
+
+// Packer returns packer function for compile entities into package
+func (g *TypeScript) Packer() base.Packer {
+	return func(entities []model.Entity) (interface{}, error) {
+		return NewTemplatePackage(entities, g.options), nil
+	}
+}
@@ -0,0 +1,41 @@
+package typescript
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"testing"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+
+	generator := New()
+
+	generator.options.Def()
+	generator.options.DDL = path.Join(path.Dir(filename), "..", "..", "test_db.sql")
+	generator.options.Output = path.Join(os.TempDir(), "typescript_test.ts")
+	generator.options.Tables = []string{"public.*", "geo.*", "shop.*"}
+	generator.options.HasMany = true
+
+	if err := generator.Generate(); err != nil {
+		t.Errorf("generate error = %v", err)
+		return
+	}
+
+	generated, err := ioutil.ReadFile(generator.options.Output)
+	if err != nil {
+		t.Errorf("file not generated = %v", err)
+	}
+
+	check, err := ioutil.ReadFile(path.Join(path.Dir(filename), "generator_test.output"))
+	if err != nil {
+		t.Errorf("check file not found = %v", err)
+	}
+
+	if string(generated) != string(check) {
+		t.Errorf("generated does not match with check")
+		return
+	}
+}
@@ -0,0 +1,188 @@
+package typescript
+
+import (
+	"encoding/json"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+var identRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TemplatePackage stores file info
+type TemplatePackage struct {
+	Enums    []TemplateEnum
+	Entities []TemplateEntity
+}
+
+// NewTemplatePackage creates a file for template
+func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackage {
+	enumsIndex := util.NewSet()
+
+	var enums []TemplateEnum
+	models := make([]TemplateEntity, len(entities))
+	for i, entity := range entities {
+		models[i] = NewTemplateEntity(entity, options)
+
+		for _, column := range entity.Columns {
+			if column.Enum != nil && enumsIndex.Add(column.Enum.PGFullName) {
+				enums = append(enums, NewTemplateEnum(*column.Enum))
+			}
+		}
+	}
+
+	return TemplatePackage{
+		Enums:    enums,
+		Entities: models,
+	}
+}
+
+// TemplateEntity stores interface info
+type TemplateEntity struct {
+	model.Entity
+
+	Fields []TemplateField
+}
+
+// NewTemplateEntity creates an interface for template
+// fields are named as json tags of model generator, relations are added as model fields have json tags too
+func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
+	var fields []TemplateField
+	for _, column := range entity.Columns {
+		fields = append(fields, NewTemplateField(util.Underscore(column.PGName), columnType(column)))
+	}
+
+	for _, relation := range entity.Relations {
+		if relation.Type != model.HasOne && !options.HasMany {
+			continue
+		}
+
+		typ := relation.GoType
+		if relation.Type != model.HasOne {
+			typ += "[]"
+		}
+
+		// nil pointers and slices of relations are marshaled as null
+		fields = append(fields, NewTemplateField(util.Underscore(relation.GoName), typ+" | null"))
+	}
+
+	return TemplateEntity{
+		Entity: entity,
+		Fields: fields,
+	}
+}
+
+// TemplateField stores interface field info
+type TemplateField struct {
+	Name template.HTML
+	Type template.HTML
+}
+
+// NewTemplateField creates a field for template, names which are not identifiers are quoted
+func NewTemplateField(name, typ string) TemplateField {
+	if !identRe.MatchString(name) {
+		name = strconv.Quote(name)
+	}
+
+	return TemplateField{
+		Name: template.HTML(name),
+		Type: template.HTML(typ),
+	}
+}
+
+// TemplateEnum stores union type info
+type TemplateEnum struct {
+	model.Enum
+
+	Union template.HTML
+}
+
+// NewTemplateEnum creates a union type of enum values for template
+func NewTemplateEnum(enum model.Enum) TemplateEnum {
+	values := make([]string, len(enum.Values))
+	for i, value := range enum.Values {
+		values[i] = literal(value)
+	}
+
+	union := strings.Join(values, " | ")
+	if union == "" {
+		union = "never"
+	}
+
+	return TemplateEnum{
+		Enum:  enum,
+		Union: template.HTML(union),
+	}
+}
+
+// columnType gets typescript type of column value as it is marshaled by encoding/json from model field
+func columnType(column model.Column) string {
+	typ := scalarType(column)
+
+	if column.IsArray {
+		dims := column.Dimensions
+		if dims == 0 {
+			dims = 1
+		}
+		typ += strings.Repeat("[]", dims)
+	}
+
+	if column.Nullable {
+		typ += " | null"
+	}
+
+	return typ
+}
+
+// scalarType gets typescript type of column element
+func scalarType(column model.Column) string {
+	if column.Enum != nil {
+		return column.Enum.GoName
+	}
+
+	switch column.PGType {
+	case model.TypePGInt2, model.TypePGInt4, model.TypePGInt8, model.TypePGFloat4, model.TypePGFloat8:
+		return "number"
+	case model.TypePGNumeric:
+		// decimal.Decimal and string set by --decimal or custom types are marshaled as strings
+		if column.GoType != model.TypeFloat64 && column.GoType != model.TypeFloat32 {
+			return "string"
+		}
+		return "number"
+	case model.TypePGInterval:
+		// time.Duration is marshaled as nanoseconds
+		return "number"
+	case model.TypePGBool:
+		return "boolean"
+	case model.TypePGText, model.TypePGVarchar, model.TypePGBpchar, model.TypePGUuid, model.TypePGPoint, model.TypePGInet,
+		model.TypePGTimestamp, model.TypePGTimestamptz, model.TypePGDate, model.TypePGTime, model.TypePGTimetz:
+		return "string"
+	case model.TypePGBytea:
+		// []byte is marshaled as base64 string
+		return "string"
+	case model.TypePGJSON, model.TypePGJSONB:
+		return "Record<string, unknown>"
+	case model.TypePGHstore:
+		return "Record<string, string>"
+	case model.TypePGCidr:
+		// net.IPNet is marshaled as struct, mask is base64 string
+		return "{ IP: string; Mask: string }"
+	}
+
+	return "unknown"
+}
+
+// literal gets typescript string literal
+func literal(s string) string {
+	// json string is valid typescript string
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return strconv.Quote(s)
+	}
+
+	return string(quoted)
+}
@@ -0,0 +1,46 @@
+package typescript
+
+import (
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+)
+
+func Test_columnType(t *testing.T) {
+	decimal := model.CustomTypeMapping{}
+	decimal.Add(model.TypePGNumeric, model.TypeDecimal, model.DecimalImport)
+
+	tests := []struct {
+		name   string
+		column model.Column
+		want   string
+	}{
+		{
+			name:   "Should get number for float numeric",
+			column: model.NewColumn("price", model.TypePGNumeric, "", false, false, false, false, 0, false, false, 0, nil, 10, nil),
+			want:   "number",
+		},
+		{
+			name:   "Should get string for decimal numeric",
+			column: model.NewColumn("price", model.TypePGNumeric, "", false, false, false, false, 0, false, false, 0, nil, 10, decimal),
+			want:   "string",
+		},
+		{
+			name:   "Should get number array for float numeric array",
+			column: model.NewColumn("prices", model.TypePGNumeric, "", false, false, false, true, 1, false, false, 0, nil, 10, nil),
+			want:   "number[]",
+		},
+		{
+			name:   "Should get string array for decimal numeric array",
+			column: model.NewColumn("prices", model.TypePGNumeric, "", false, false, false, true, 1, false, false, 0, nil, 10, decimal),
+			want:   "string[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := columnType(tt.column); got != tt.want {
+				t.Errorf("columnType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package typescript
+
+import (
+	"github.com/dizzyfool/genna/generators/base"
+)
+
+// Options for generator
+type Options struct {
+	base.Options
+
+	// Add has-many and many2many relations to interfaces
+	HasMany bool
+}
+
+// Def fills default values of an options
+func (o *Options) Def() {
+	o.Options.Def()
+}
@@ -0,0 +1,11 @@
+package typescript
+
+// Template is used for typescript file
+const Template = `// Code generated by genna. DO NOT EDIT.
+{{range .Enums}}
+export type {{.GoName}} = {{.Union}};
+{{end}}{{range .Entities}}
+export interface {{.GoName}} {{"{"}}{{range .Fields}}
+  {{.Name}}: {{.Type}};{{end}}
+}
+{{end}}`
@@ -0,0 +1,799 @@
+package validate
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/lib"
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+// expressions of check constraint supported by parser
+type (
+	checkExpr interface{}
+
+	// checkAnd is a conjunction, every condition is translated separately
+	checkAnd []checkExpr
+	// checkUnsupported is any other expression, e.g. or, is null, like
+	checkUnsupported struct{}
+
+	checkColumn string
+	checkConst  struct {
+		value    string
+		isString bool
+	}
+	checkCall struct {
+		name string
+		args []checkExpr
+	}
+	checkArray []checkExpr
+
+	// checkCompare is a comparison, also used for regular expression match
+	checkCompare struct {
+		op          string
+		left, right checkExpr
+	}
+	checkBetween struct {
+		value, low, high checkExpr
+	}
+	// checkIn is a list of allowed values, or disallowed ones if not is set
+	checkIn struct {
+		value  checkExpr
+		values []checkExpr
+		not    bool
+	}
+)
+
+// numericTypes are types in casts which make number from string constant, e.g. '-1'::integer
+var numericTypes = map[string]bool{
+	"smallint": true, "integer": true, "bigint": true, "int": true, "int2": true, "int4": true, "int8": true,
+	"numeric": true, "decimal": true, "real": true, "double": true, "float4": true, "float8": true,
+}
+
+// checkKeywords end type name in casts
+var checkKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "between": true, "in": true, "is": true,
+	"like": true, "ilike": true, "similar": true, "collate": true, "any": true, "all": true,
+}
+
+// checkParser parses expression of check constraint
+type checkParser struct {
+	tokens []genna.Token
+	pos    int
+}
+
+// parseCheck parses expression of check constraint as it is written in DDL or returned by pg_get_constraintdef
+func parseCheck(expression string) (checkExpr, error) {
+	tokens, err := genna.Lex(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &checkParser{tokens: tokens}
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected '%s'", p.peek().Value)
+	}
+
+	return expr, nil
+}
+
+func (p *checkParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *checkParser) peek() genna.Token {
+	if p.done() {
+		return genna.Token{Kind: genna.TokenSymbol}
+	}
+
+	return p.tokens[p.pos]
+}
+
+// accept consumes keyword or symbol if matched
+func (p *checkParser) accept(value string) bool {
+	if p.peek().Is(value) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *checkParser) expect(value string) error {
+	if !p.accept(value) {
+		return fmt.Errorf("'%s' expected", value)
+	}
+
+	return nil
+}
+
+func (p *checkParser) or() (checkExpr, error) {
+	expr, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("or") {
+		if _, err := p.and(); err != nil {
+			return nil, err
+		}
+		expr = checkUnsupported{}
+	}
+
+	return expr, nil
+}
+
+func (p *checkParser) and() (checkExpr, error) {
+	expr, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.peek().Is("and") {
+		return expr, nil
+	}
+
+	result := checkAnd{expr}
+	for p.accept("and") {
+		next, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, next)
+	}
+
+	return result, nil
+}
+
+func (p *checkParser) not() (checkExpr, error) {
+	if p.accept("not") {
+		if _, err := p.not(); err != nil {
+			return nil, err
+		}
+		return checkUnsupported{}, nil
+	}
+
+	return p.compare()
+}
+
+func (p *checkParser) compare() (checkExpr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	not := p.accept("not")
+
+	switch {
+	case p.accept("between"):
+		low, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("and"); err != nil {
+			return nil, err
+		}
+		high, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if not {
+			return checkUnsupported{}, nil
+		}
+		return checkBetween{value: left, low: low, high: high}, nil
+	case p.accept("in"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		values, err := p.list(")")
+		if err != nil {
+			return nil, err
+		}
+		return checkIn{value: left, values: values, not: not}, nil
+	case not:
+		// not like, not similar to, etc.
+		p.rest()
+		return checkUnsupported{}, nil
+	case p.accept("is"), p.accept("like"), p.accept("ilike"), p.accept("similar"):
+		p.rest()
+		return checkUnsupported{}, nil
+	}
+
+	t := p.peek()
+	if t.Kind != genna.TokenSymbol {
+		return left, nil
+	}
+
+	switch t.Value {
+	case "=", "<>", "!=", "<", "<=", ">", ">=", "~", "~*", "!~", "!~*":
+		p.pos++
+	default:
+		return left, nil
+	}
+
+	// = ANY (ARRAY[...]) and <> ALL (ARRAY[...]) are written by postgres for in and not in
+	if isAny, isAll := p.accept("any"), p.accept("all"); isAny || isAll {
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		array, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		values, ok := array.(checkArray)
+		switch {
+		case !ok:
+			return checkUnsupported{}, nil
+		case isAny && t.Value == "=":
+			return checkIn{value: left, values: values}, nil
+		case isAll && (t.Value == "<>" || t.Value == "!="):
+			return checkIn{value: left, values: values, not: true}, nil
+		}
+		return checkUnsupported{}, nil
+	}
+
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	return checkCompare{op: t.Value, left: left, right: right}, nil
+}
+
+// operand parses value with optional casts, arithmetic is not supported
+func (p *checkParser) operand() (checkExpr, error) {
+	expr, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("::") {
+		typ := p.typeName()
+		if c, ok := expr.(checkConst); ok && c.isString && numericTypes[typ] {
+			if _, err := strconv.ParseFloat(c.value, 64); err == nil {
+				expr = checkConst{value: c.value}
+			}
+		}
+	}
+
+	if t := p.peek(); t.Kind == genna.TokenSymbol && (t.Value == "+" || t.Value == "-" || t.Value == "*" || t.Value == "/" || t.Value == "%") {
+		p.pos++
+		if _, err := p.operand(); err != nil {
+			return nil, err
+		}
+		return checkUnsupported{}, nil
+	}
+
+	return expr, nil
+}
+
+// typeName consumes type name of cast and gets its first word
+func (p *checkParser) typeName() string {
+	name := ""
+	for t := p.peek(); (t.Kind == genna.TokenWord || t.Kind == genna.TokenIdent) && !checkKeywords[t.Value]; t = p.peek() {
+		if name == "" {
+			name = t.Value
+		}
+		p.pos++
+	}
+
+	for {
+		switch {
+		case p.peek().Is("("):
+			p.pos++
+			_, _ = p.list(")")
+		case p.peek().Is("["):
+			p.pos++
+			_, _ = p.list("]")
+		default:
+			return name
+		}
+	}
+}
+
+func (p *checkParser) primary() (checkExpr, error) {
+	t := p.peek()
+	p.pos++
+
+	switch {
+	case t.Is("("):
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expect(")")
+	case t.Is("-") || t.Is("+"):
+		next := p.peek()
+		if next.Kind != genna.TokenNumber {
+			return nil, fmt.Errorf("number expected")
+		}
+		p.pos++
+		if t.Is("-") {
+			return checkConst{value: "-" + next.Value}, nil
+		}
+		return checkConst{value: next.Value}, nil
+	case t.Kind == genna.TokenNumber:
+		return checkConst{value: t.Value}, nil
+	case t.Kind == genna.TokenString:
+		return checkConst{value: t.Value, isString: true}, nil
+	case t.Is("array") && p.peek().Is("["):
+		p.pos++
+		values, err := p.list("]")
+		return checkArray(values), err
+	case t.Is("true"), t.Is("false"), t.Is("null"):
+		return checkUnsupported{}, nil
+	case t.Kind == genna.TokenWord || t.Kind == genna.TokenIdent:
+		if !p.accept("(") {
+			return checkColumn(t.Value), nil
+		}
+		args, err := p.list(")")
+		if err != nil {
+			return nil, err
+		}
+		return checkCall{name: t.Value, args: args}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected '%s'", t.Value)
+}
+
+// list parses comma separated operands till closing symbol
+func (p *checkParser) list(closing string) ([]checkExpr, error) {
+	var result []checkExpr
+	if p.accept(closing) {
+		return result, nil
+	}
+
+	for {
+		expr, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expr)
+
+		if p.accept(closing) {
+			return result, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rest skips the rest of current condition
+func (p *checkParser) rest() {
+	depth := 0
+	for !p.done() {
+		t := p.peek()
+		switch {
+		case t.Is("("):
+			depth++
+		case t.Is(")"):
+			if depth == 0 {
+				return
+			}
+			depth--
+		case depth == 0 && (t.Is("and") || t.Is("or")):
+			return
+		}
+		p.pos++
+	}
+}
+
+// errUnsupported is returned for checks which could not be translated to go code
+var errUnsupported = fmt.Errorf("check is not supported")
+
+// lengthFunctions get length of string in characters
+var lengthFunctions = map[string]bool{"length": true, "char_length": true, "character_length": true}
+
+// checkTranslator translates check constraints of entity to go conditions
+type checkTranslator struct {
+	entity  model.Entity
+	options Options
+
+	patterns []TemplatePattern
+	names    util.Index
+
+	// domain is a column checked by domain constraint, VALUE in expression refers to it
+	domain *model.Column
+}
+
+func newCheckTranslator(entity model.Entity, options Options) *checkTranslator {
+	return &checkTranslator{
+		entity:  entity,
+		options: options,
+		names:   util.NewIndex(),
+	}
+}
+
+// translate translates all conditions of check constraint or returns error if any of them is not supported
+func (t *checkTranslator) translate(check model.Check) ([]TemplateCheck, []TemplatePattern, error) {
+	expr, err := parseCheck(check.Expression)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var checks []TemplateCheck
+	var patterns []TemplatePattern
+	for _, condition := range conditions(expr) {
+		translated, pattern, err := t.condition(condition)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		checks = append(checks, translated...)
+		if pattern != nil {
+			patterns = append(patterns, *pattern)
+		}
+	}
+
+	for _, pattern := range patterns {
+		t.names.Add(pattern.Name)
+	}
+
+	return checks, patterns, nil
+}
+
+// translateDomain translates check constraint of column domain
+func (t *checkTranslator) translateDomain(column model.Column, check model.Check) ([]TemplateCheck, []TemplatePattern, error) {
+	t.domain = &column
+	defer func() { t.domain = nil }()
+
+	return t.translate(check)
+}
+
+// conditions gets conditions of conjunction
+func conditions(expr checkExpr) []checkExpr {
+	and, ok := expr.(checkAnd)
+	if !ok {
+		return []checkExpr{expr}
+	}
+
+	var result []checkExpr
+	for _, e := range and {
+		result = append(result, conditions(e)...)
+	}
+
+	return result
+}
+
+func (t *checkTranslator) condition(expr checkExpr) ([]TemplateCheck, *TemplatePattern, error) {
+	switch e := expr.(type) {
+	case checkCompare:
+		return t.compare(e)
+	case checkBetween:
+		low, _, err := t.compare(checkCompare{op: ">=", left: e.value, right: e.low})
+		if err != nil {
+			return nil, nil, err
+		}
+		high, _, err := t.compare(checkCompare{op: "<=", left: e.value, right: e.high})
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(low, high...), nil, nil
+	case checkIn:
+		check, err := t.in(e)
+		return []TemplateCheck{check}, nil, err
+	}
+
+	return nil, nil, errUnsupported
+}
+
+// compare translates comparison of column or its length with constant and regular expression match
+func (t *checkTranslator) compare(e checkCompare) ([]TemplateCheck, *TemplatePattern, error) {
+	op, left, right := e.op, e.left, e.right
+	if _, ok := left.(checkConst); ok {
+		if op = flip(op); op == "" {
+			return nil, nil, errUnsupported
+		}
+		left, right = right, left
+	}
+
+	value, ok := right.(checkConst)
+	if !ok {
+		return nil, nil, errUnsupported
+	}
+
+	switch l := left.(type) {
+	case checkColumn:
+		field, err := t.field(l)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch op {
+		case "~", "~*", "!~", "!~*":
+			return t.match(field, op, value)
+		}
+
+		check, err := compareValue(field, op, value)
+		return []TemplateCheck{check}, nil, err
+	case checkCall:
+		if !lengthFunctions[l.name] || len(l.args) != 1 {
+			return nil, nil, errUnsupported
+		}
+
+		column, ok := l.args[0].(checkColumn)
+		if !ok {
+			return nil, nil, errUnsupported
+		}
+
+		field, err := t.field(column)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		check, err := compareLength(field, op, value)
+		return []TemplateCheck{check}, nil, err
+	}
+
+	return nil, nil, errUnsupported
+}
+
+// in translates list of allowed or disallowed values
+func (t *checkTranslator) in(e checkIn) (TemplateCheck, error) {
+	column, ok := e.value.(checkColumn)
+	if !ok || len(e.values) == 0 {
+		return TemplateCheck{}, errUnsupported
+	}
+
+	field, err := t.field(column)
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+
+	op, join := "!=", " && "
+	if e.not {
+		op, join = "==", " || "
+	}
+
+	parts := make([]string, len(e.values))
+	for i, v := range e.values {
+		value, ok := v.(checkConst)
+		if !ok {
+			return TemplateCheck{}, errUnsupported
+		}
+
+		literal, err := field.literal(value)
+		if err != nil {
+			return TemplateCheck{}, err
+		}
+
+		parts[i] = fmt.Sprintf("%s %s %s", field.value, op, literal)
+	}
+
+	condition := strings.Join(parts, join)
+	if e.not && len(parts) > 1 {
+		condition = "(" + condition + ")"
+	}
+
+	return field.check(condition, codeWrongValue, ""), nil
+}
+
+// match translates regular expression match, pattern should be supported by go
+func (t *checkTranslator) match(field checkField, op string, value checkConst) ([]TemplateCheck, *TemplatePattern, error) {
+	if field.column.GoType != model.TypeString || !value.isString {
+		return nil, nil, errUnsupported
+	}
+
+	expression := value.value
+	if op == "~*" || op == "!~*" {
+		expression = "(?i)" + expression
+	}
+
+	if _, err := regexp.Compile(expression); err != nil {
+		return nil, nil, errUnsupported
+	}
+
+	pattern := TemplatePattern{
+		Name:  t.names.GetNext(util.LowerFirst(t.entity.GoName) + field.GoName + "Pattern"),
+		Value: rawString(expression),
+	}
+
+	condition := fmt.Sprintf("!%s.MatchString(%s)", pattern.Name, field.text())
+	if op == "!~" || op == "!~*" {
+		condition = fmt.Sprintf("%s.MatchString(%s)", pattern.Name, field.text())
+	}
+
+	return []TemplateCheck{field.check(condition, codePattern, "regexp")}, &pattern, nil
+}
+
+// compareValue translates comparison of column with constant
+func compareValue(field checkField, op string, value checkConst) (TemplateCheck, error) {
+	literal, err := field.literal(value)
+	if err != nil {
+		return TemplateCheck{}, err
+	}
+
+	// strings are compared by collation in postgres
+	if field.column.GoType == model.TypeString && op != "=" && op != "<>" && op != "!=" {
+		return TemplateCheck{}, errUnsupported
+	}
+
+	code := ""
+	switch op {
+	case ">", ">=":
+		code = codeMinValue
+	case "<", "<=":
+		code = codeMaxValue
+	case "=", "<>", "!=":
+		code = codeWrongValue
+	default:
+		return TemplateCheck{}, errUnsupported
+	}
+
+	return field.check(fmt.Sprintf("%s %s %s", field.value, negate(op), literal), code, ""), nil
+}
+
+// compareLength translates comparison of string length with constant
+func compareLength(field checkField, op string, value checkConst) (TemplateCheck, error) {
+	if field.column.GoType != model.TypeString || value.isString {
+		return TemplateCheck{}, errUnsupported
+	}
+
+	if _, err := strconv.ParseInt(value.value, 10, 64); err != nil {
+		return TemplateCheck{}, errUnsupported
+	}
+
+	code := ""
+	switch op {
+	case ">", ">=":
+		code = codeMinLength
+	case "<", "<=":
+		code = codeMaxLength
+	case "=", "<>", "!=":
+		code = codeWrongValue
+	default:
+		return TemplateCheck{}, errUnsupported
+	}
+
+	condition := fmt.Sprintf("utf8.RuneCountInString(%s) %s %s", field.text(), negate(op), value.value)
+
+	return field.check(condition, code, "unicode/utf8"), nil
+}
+
+// checkField is a field of model used in check
+type checkField struct {
+	GoName string
+	column model.Column
+
+	// value is field value in condition, guard checks if pointer is not nil
+	value string
+	guard string
+}
+
+// field finds column used in check
+func (t *checkTranslator) field(name checkColumn) (checkField, error) {
+	if t.domain != nil {
+		if string(name) != "value" {
+			return checkField{}, errUnsupported
+		}
+		return t.newField(*t.domain)
+	}
+
+	for _, column := range t.entity.Columns {
+		if column.PGName == string(name) {
+			return t.newField(column)
+		}
+	}
+
+	return checkField{}, errUnsupported
+}
+
+func (t *checkTranslator) newField(column model.Column) (checkField, error) {
+	if column.IsArray {
+		return checkField{}, errUnsupported
+	}
+
+	goName := t.options.Overrides.FieldName(t.entity, column, t.options.KeepPK)
+
+	field := checkField{
+		GoName: goName,
+		column: column,
+		value:  "m." + goName,
+	}
+
+	if column.Nullable {
+		field.value = "*m." + goName
+		field.guard = fmt.Sprintf("m.%s != nil && ", goName)
+	}
+
+	return field, nil
+}
+
+// text gets field value as string, domain fields could have named type
+func (f checkField) text() string {
+	if f.column.Domain != nil {
+		return fmt.Sprintf("string(%s)", f.value)
+	}
+
+	return f.value
+}
+
+// literal gets go constant for column value
+func (f checkField) literal(value checkConst) (string, error) {
+	switch f.column.GoType {
+	case model.TypeString:
+		if value.isString {
+			return strconv.Quote(value.value), nil
+		}
+	case model.TypeInt, model.TypeInt32, model.TypeInt64:
+		if _, err := strconv.ParseInt(value.value, 10, 64); err == nil && !value.isString {
+			return value.value, nil
+		}
+	case model.TypeFloat32, model.TypeFloat64:
+		if _, err := strconv.ParseFloat(value.value, 64); err == nil && !value.isString {
+			return value.value, nil
+		}
+	}
+
+	return "", errUnsupported
+}
+
+// check creates check with condition which is true for wrong value
+func (f checkField) check(condition, code, imp string) TemplateCheck {
+	// NULL passes check constraint in postgres, so nil pointers are not checked
+	return TemplateCheck{
+		GoName:    f.GoName,
+		Condition: template.HTML(f.guard + condition),
+		Error:     code,
+		Import:    imp,
+	}
+}
+
+// flip gets operator for swapped operands
+func flip(op string) string {
+	switch op {
+	case ">":
+		return "<"
+	case ">=":
+		return "<="
+	case "<":
+		return ">"
+	case "<=":
+		return ">="
+	case "=", "<>", "!=":
+		return op
+	}
+
+	return ""
+}
+
+// negate gets operator which is true when value does not satisfy check
+func negate(op string) string {
+	switch op {
+	case ">":
+		return "<="
+	case ">=":
+		return "<"
+	case "<":
+		return ">="
+	case "<=":
+		return ">"
+	case "=":
+		return "!="
+	}
+
+	return "=="
+}
+
+// rawString gets go string literal, raw one if possible
+func rawString(s string) template.HTML {
+	if strings.Contains(s, "`") || strings.ContainsAny(s, "\r\n") {
+		return template.HTML(strconv.Quote(s))
+	}
+
+	return template.HTML("`" + s + "`")
+}
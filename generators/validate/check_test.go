@@ -0,0 +1,211 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+func Test_checkTranslator_translate(t *testing.T) {
+	entity := model.NewEntity(util.PublicSchema, "items", []model.Column{
+		model.NewColumn("itemId", model.TypePGInt4, "", true, false, false, false, 0, true, false, 0, nil, 10, nil),
+		model.NewColumn("price", model.TypePGNumeric, "", false, false, false, false, 0, false, false, 0, nil, 10, nil),
+		model.NewColumn("qty", model.TypePGInt4, "", false, true, false, false, 0, false, false, 0, nil, 10, nil),
+		model.NewColumn("code", model.TypePGVarchar, "", false, false, false, false, 0, false, false, 8, nil, 10, nil),
+		model.NewColumn("tags", model.TypePGText, "", false, false, false, true, 1, false, false, 0, nil, 10, nil),
+	}, nil)
+
+	tests := []struct {
+		name       string
+		expression string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "Should translate comparison",
+			expression: "price > 0",
+			want:       []string{"m.Price <= 0 ErrMinValue"},
+		},
+		{
+			name:       "Should translate comparison with constant first",
+			expression: "0 <= qty",
+			want:       []string{"m.Qty != nil && *m.Qty < 0 ErrMinValue"},
+		},
+		{
+			name:       "Should translate comparison read from database",
+			expression: "price > (0)::numeric",
+			want:       []string{"m.Price <= 0 ErrMinValue"},
+		},
+		{
+			name:       "Should translate negative constant read from database",
+			expression: `price >= ('-1.5'::numeric)::double precision`,
+			want:       []string{"m.Price < -1.5 ErrMinValue"},
+		},
+		{
+			name:       "Should translate primary key",
+			expression: `"itemId" <> 0`,
+			want:       []string{"m.ID == 0 ErrWrongValue"},
+		},
+		{
+			name:       "Should translate between",
+			expression: "qty between 1 and 10",
+			want:       []string{"m.Qty != nil && *m.Qty < 1 ErrMinValue", "m.Qty != nil && *m.Qty > 10 ErrMaxValue"},
+		},
+		{
+			name:       "Should translate conjunction read from database",
+			expression: "(qty >= 1) AND (qty <= 10)",
+			want:       []string{"m.Qty != nil && *m.Qty < 1 ErrMinValue", "m.Qty != nil && *m.Qty > 10 ErrMaxValue"},
+		},
+		{
+			name:       "Should translate length",
+			expression: "length((code)::text) >= 3",
+			want:       []string{"utf8.RuneCountInString(m.Code) < 3 ErrMinLength"},
+		},
+		{
+			name:       "Should translate in",
+			expression: "code in ('a', 'b')",
+			want:       []string{`m.Code != "a" && m.Code != "b" ErrWrongValue`},
+		},
+		{
+			name:       "Should translate in read from database",
+			expression: "(code)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[])",
+			want:       []string{`m.Code != "a" && m.Code != "b" ErrWrongValue`},
+		},
+		{
+			name:       "Should translate not in",
+			expression: "qty NOT IN (1, 2)",
+			want:       []string{"m.Qty != nil && (*m.Qty == 1 || *m.Qty == 2) ErrWrongValue"},
+		},
+		{
+			name:       "Should translate regular expression",
+			expression: "(code)::text ~* '^[a-z]+$'::text",
+			want:       []string{"!itemCodePattern.MatchString(m.Code) ErrPattern"},
+		},
+		{
+			name:       "Should not translate comparison of columns",
+			expression: "qty < price",
+			wantErr:    true,
+		},
+		{
+			name:       "Should not translate disjunction",
+			expression: "qty > 0 or qty is null",
+			wantErr:    true,
+		},
+		{
+			name:       "Should not translate partially",
+			expression: "price > 0 and code like 'a%'",
+			wantErr:    true,
+		},
+		{
+			name:       "Should not translate string order",
+			expression: "code > 'a'",
+			wantErr:    true,
+		},
+		{
+			name:       "Should not translate fraction for integer",
+			expression: "qty > 0.5",
+			wantErr:    true,
+		},
+		{
+			name:       "Should not translate arrays",
+			expression: "array_length(tags, 1) > 0",
+			wantErr:    true,
+		},
+		{
+			name:       "Should not translate unsupported regular expression",
+			expression: `code ~ '^(a)\1$'`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translator := newCheckTranslator(entity, Options{})
+
+			checks, _, err := translator.translate(model.NewCheck("check", tt.expression, nil))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("translate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var got []string
+			for _, check := range checks {
+				got = append(got, string(check.Condition)+" "+check.Error)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("translate() = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("translate()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_checkTranslator_translateDomain(t *testing.T) {
+	code := model.NewColumn("code", model.TypePGVarchar, "", false, true, false, false, 0, false, false, 8, nil, 10, nil)
+	code.AddDomain(model.NewDomain(util.PublicSchema, "code", nil))
+
+	entity := model.NewEntity(util.PublicSchema, "items", []model.Column{
+		model.NewColumn("value", model.TypePGInt4, "", false, false, false, false, 0, false, false, 0, nil, 10, nil),
+		code,
+	}, nil)
+
+	tests := []struct {
+		name       string
+		expression string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "Should translate value",
+			expression: "VALUE <> 'none'",
+			want:       []string{`m.Code != nil && *m.Code == "none" ErrWrongValue`},
+		},
+		{
+			name:       "Should translate length of value as string",
+			expression: "length((VALUE)::text) >= 2",
+			want:       []string{"m.Code != nil && utf8.RuneCountInString(string(*m.Code)) < 2 ErrMinLength"},
+		},
+		{
+			name:       "Should translate regular expression of value as string",
+			expression: "(VALUE)::text ~ '^[a-z]+$'::text",
+			want:       []string{"m.Code != nil && !itemCodePattern.MatchString(string(*m.Code)) ErrPattern"},
+		},
+		{
+			name:       "Should not translate other columns",
+			expression: "code <> 'none'",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translator := newCheckTranslator(entity, Options{})
+
+			checks, _, err := translator.translateDomain(code, model.NewCheck("code_check", tt.expression, nil))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("translateDomain() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var got []string
+			for _, check := range checks {
+				got = append(got, string(check.Condition)+" "+check.Error)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("translateDomain() = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("translateDomain()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -49,8 +49,7 @@ func (g *Validate) AddFlags(command *cobra.Command) {
 func (g *Validate) ReadFlags(command *cobra.Command) error {
 	var err error
 
-	g.options.URL, g.options.Output, g.options.Package, g.options.Tables, g.options.FollowFKs, g.options.GoPgVer, g.options.CustomTypes, err = base.ReadFlags(command)
-	if err != nil {
+	if g.options.Options, g.options.Package, err = base.ReadFlags(command); err != nil {
 		return err
 	}
 
@@ -68,17 +67,22 @@ func (g *Validate) ReadFlags(command *cobra.Command) error {
 
 // Generate runs whole generation process
 func (g *Validate) Generate() error {
-	return base.NewGenerator(g.options.URL).
-		Generate(
-			g.options.Tables,
-			g.options.FollowFKs,
-			false,
-			g.options.Output,
-			Template,
-			g.Packer(),
-			g.options.GoPgVer,
-			g.options.CustomTypes,
-		)
+	return g.Repack(g.Packer())
+}
+
+// Repack runs generator with custom packer
+func (g *Validate) Repack(packer base.Packer) error {
+	generator := base.NewGeneratorFromOptions(g.options.Options)
+	if g.options.MultiFile {
+		return generator.GenerateMulti(g.options.Options, false, MultiTemplate, packer)
+	}
+
+	return generator.Generate(
+		g.options.Options,
+		false,
+		Template,
+		packer,
+	)
 }
 
 // Packer returns packer function for compile entities into package
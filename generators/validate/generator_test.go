@@ -40,3 +40,36 @@ func TestGenerator_Generate(t *testing.T) {
 		return
 	}
 }
+
+func TestGenerator_GenerateChecks(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+
+	generator := New()
+
+	generator.options.Def()
+	generator.options.DDL = path.Join(path.Dir(filename), "..", "..", "test_db.sql")
+	generator.options.Output = path.Join(os.TempDir(), "validate_checks_test.go")
+	generator.options.Package = "model"
+	generator.options.Tables = []string{"shop.*"}
+	generator.options.CustomTypes.Add(model.TypePGNumeric, model.TypeDecimal, model.DecimalImport)
+
+	if err := generator.Generate(); err != nil {
+		t.Errorf("generate error = %v", err)
+		return
+	}
+
+	generated, err := ioutil.ReadFile(generator.options.Output)
+	if err != nil {
+		t.Errorf("file not generated = %v", err)
+	}
+
+	check, err := ioutil.ReadFile(path.Join(path.Dir(filename), "checks_test.output"))
+	if err != nil {
+		t.Errorf("check file not found = %v", err)
+	}
+
+	if string(generated) != string(check) {
+		t.Errorf("generated does not match with check")
+		return
+	}
+}
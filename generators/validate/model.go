@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"strings"
 
+	"github.com/dizzyfool/genna/generators/base"
 	"github.com/dizzyfool/genna/model"
 	"github.com/dizzyfool/genna/util"
 )
@@ -24,6 +25,20 @@ const (
 	Enum = "enum"
 	// PEnum is allowed values check types for pointers
 	PEnum = "penum"
+	// Decimal is precision and scale check types
+	Decimal = "decimal"
+	// PDecimal is precision and scale check types for pointers
+	PDecimal = "pdecimal"
+)
+
+// names of error constants used by checks translated from check constraints
+const (
+	codeWrongValue = "ErrWrongValue"
+	codeMinValue   = "ErrMinValue"
+	codeMaxValue   = "ErrMaxValue"
+	codeMinLength  = "ErrMinLength"
+	codeMaxLength  = "ErrMaxLength"
+	codePattern    = "ErrPattern"
 )
 
 // TemplatePackage stores package info
@@ -42,8 +57,13 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 
 	var models []TemplateEntity
 	for _, entity := range entities {
+		// views can not be written, nothing to validate
+		if entity.ReadOnly() {
+			continue
+		}
+
 		mdl := NewTemplateEntity(entity, options)
-		if len(mdl.Columns) == 0 {
+		if len(mdl.Columns) == 0 && len(mdl.Checks) == 0 && len(mdl.Skipped) == 0 {
 			continue
 		}
 
@@ -55,7 +75,7 @@ func NewTemplatePackage(entities []model.Entity, options Options) TemplatePackag
 	}
 
 	return TemplatePackage{
-		Package: options.Package,
+		Package: base.PackageName(options.Options, options.Package, entities),
 
 		HasImports: imports.Len() > 0,
 		Imports:    imports.Elements(),
@@ -69,6 +89,15 @@ type TemplateEntity struct {
 	model.Entity
 
 	Columns []TemplateColumn
+
+	// Checks are translated check constraints, Patterns are regular expressions used by them
+	Checks   []TemplateCheck
+	Patterns []TemplatePattern
+
+	// Skipped are check constraints which could not be translated
+	HasSkipped bool
+	Skipped    []template.HTML
+
 	Imports []string
 }
 
@@ -86,7 +115,7 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 			continue
 		}
 
-		tmpl := NewTemplateColumn(column, options)
+		tmpl := NewTemplateColumn(entity, column, options)
 
 		columns = append(columns, tmpl)
 		if tmpl.Import != "" {
@@ -94,10 +123,62 @@ func NewTemplateEntity(entity model.Entity, options Options) TemplateEntity {
 		}
 	}
 
+	translator := newCheckTranslator(entity, options)
+
+	var checks []TemplateCheck
+	var patterns []TemplatePattern
+	var skipped []template.HTML
+	for _, check := range entity.Checks {
+		translated, pts, err := translator.translate(check)
+		if err != nil {
+			skipped = append(skipped, template.HTML(fmt.Sprintf("%s: %s", check.Name, strings.Join(strings.Fields(check.Expression), " "))))
+			continue
+		}
+
+		for _, tmpl := range translated {
+			if tmpl.Import != "" {
+				imports.Add(tmpl.Import)
+			}
+		}
+
+		checks = append(checks, translated...)
+		patterns = append(patterns, pts...)
+	}
+
+	for _, column := range entity.Columns {
+		if column.Domain == nil {
+			continue
+		}
+
+		for _, check := range column.Domain.Checks {
+			translated, pts, err := translator.translateDomain(column, check)
+			if err != nil {
+				skipped = append(skipped, template.HTML(fmt.Sprintf("%s (%s): %s", check.Name, column.PGName, strings.Join(strings.Fields(check.Expression), " "))))
+				continue
+			}
+
+			for _, tmpl := range translated {
+				if tmpl.Import != "" {
+					imports.Add(tmpl.Import)
+				}
+			}
+
+			checks = append(checks, translated...)
+			patterns = append(patterns, pts...)
+		}
+	}
+
 	return TemplateEntity{
 		Entity: entity,
 
 		Columns: columns,
+
+		Checks:   checks,
+		Patterns: patterns,
+
+		HasSkipped: len(skipped) > 0,
+		Skipped:    skipped,
+
 		Imports: imports.Elements(),
 	}
 }
@@ -109,14 +190,18 @@ type TemplateColumn struct {
 	Check string
 	Enum  template.HTML
 
+	// Digits is a number of digits allowed before decimal point
+	Digits int
+
+	// Value is a string value of field for length checks, domain fields could have named type
+	Value template.HTML
+
 	Import string
 }
 
 // NewTemplateColumn creates a column for template
-func NewTemplateColumn(column model.Column, options Options) TemplateColumn {
-	if !options.KeepPK && column.IsPK {
-		column.GoName = util.ID
-	}
+func NewTemplateColumn(entity model.Entity, column model.Column, options Options) TemplateColumn {
+	column.GoName = options.Overrides.FieldName(entity, column, options.KeepPK)
 
 	tmpl := TemplateColumn{
 		Column: column,
@@ -130,11 +215,42 @@ func NewTemplateColumn(column model.Column, options Options) TemplateColumn {
 
 	if tmpl.Check == PLen || tmpl.Check == Len {
 		tmpl.Import = "unicode/utf8"
+
+		tmpl.Value = template.HTML("m." + column.GoName)
+		if tmpl.Check == PLen {
+			tmpl.Value = "*" + tmpl.Value
+		}
+		if column.Domain != nil {
+			tmpl.Value = "string(" + tmpl.Value + ")"
+		}
+	}
+
+	if tmpl.Check == PDecimal || tmpl.Check == Decimal {
+		tmpl.Digits = column.Precision - column.Scale
+		tmpl.Import = model.DecimalImport
 	}
 
 	return tmpl
 }
 
+// TemplateCheck stores condition translated from check constraint
+type TemplateCheck struct {
+	// GoName is a name of checked field
+	GoName string
+
+	// Condition is true if value is wrong
+	Condition template.HTML
+	Error     string
+
+	Import string
+}
+
+// TemplatePattern stores regular expression used by checks
+type TemplatePattern struct {
+	Name  string
+	Value template.HTML
+}
+
 // isValidatable checks if field can be validated
 func isValidatable(c model.Column) bool {
 	// validate FK
@@ -157,9 +273,19 @@ func isValidatable(c model.Column) bool {
 		return true
 	}
 
+	// validate numeric precision, float can't be checked reliably
+	if isDecimal(c) {
+		return true
+	}
+
 	return false
 }
 
+// isDecimal checks if column is numeric with precision mapped to shopspring decimal
+func isDecimal(c model.Column) bool {
+	return !c.IsArray && c.GoType == model.TypeDecimal && c.Import == model.DecimalImport && c.Precision > 0
+}
+
 // check return check type for validation
 func check(c model.Column) string {
 	if !isValidatable(c) {
@@ -191,5 +317,12 @@ func check(c model.Column) string {
 		return Enum
 	}
 
+	if isDecimal(c) {
+		if c.Nullable {
+			return PDecimal
+		}
+		return Decimal
+	}
+
 	return ""
 }
@@ -1,20 +1,40 @@
 package validate
 
-const Template = `//nolint
+import "github.com/dizzyfool/genna/generators/base"
+
+const Template = templateHeader + templateErrors + templateModels
+
+// MultiTemplate is used for multi-file output: common code goes to shared file, validate code of models to their own files
+var MultiTemplate = base.MultiTemplate{
+	Name:   "validate",
+	Shared: templateHeader + templateErrors,
+	Entity: templateHeader + templateModels,
+}
+
+const templateHeader = `//nolint
 //lint:file-ignore U1000 ignore unused code, it's generated
 package {{.Package}}{{if .HasImports}}
 
 import ({{range .Imports}}
     "{{.}}"{{end}}
 ){{end}}
+`
 
+const templateErrors = `
 const (
 	ErrEmptyValue = "empty"
 	ErrMaxLength  = "len"
 	ErrWrongValue = "value"
+	ErrMinLength  = "min_len"
+	ErrMinValue   = "min"
+	ErrMaxValue   = "max"
+	ErrPattern    = "pattern"
+	ErrScale      = "scale"
 )
 
-{{range $model := .Entities}}
+`
+
+const templateModels = `{{range $model := .Entities}}
 func (m {{.GoName}}) Validate() (errors map[string]string, valid bool) {
 	errors = map[string]string{}
 
@@ -32,11 +52,11 @@ func (m {{.GoName}}) Validate() (errors map[string]string, valid bool) {
 		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrEmptyValue
 	}
 	{{else if eq .Check "len"}}
-	if utf8.RuneCountInString(m.{{.GoName}}) > {{.MaxLen}} {
+	if utf8.RuneCountInString({{.Value}}) > {{.MaxLen}} {
 		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrMaxLength
 	}
 	{{else if eq .Check "plen"}}
-	if m.{{.GoName}} != nil && utf8.RuneCountInString(*m.{{.GoName}}) > {{.MaxLen}} {
+	if m.{{.GoName}} != nil && utf8.RuneCountInString({{.Value}}) > {{.MaxLen}} {
 		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrMaxLength
 	}
 	{{else if eq .Check "enum"}}
@@ -53,10 +73,31 @@ func (m {{.GoName}}) Validate() (errors map[string]string, valid bool) {
 				errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrWrongValue
 		}
 	}
+	{{else if eq .Check "decimal"}}
+	if m.{{.GoName}}.Abs().GreaterThanOrEqual(decimal.New(1, {{.Digits}})) {
+		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrMaxValue
+	} else if !m.{{.GoName}}.Equal(m.{{.GoName}}.Truncate({{.Scale}})) {
+		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrScale
+	}
+	{{else if eq .Check "pdecimal"}}
+	if m.{{.GoName}} != nil && m.{{.GoName}}.Abs().GreaterThanOrEqual(decimal.New(1, {{.Digits}})) {
+		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrMaxValue
+	} else if m.{{.GoName}} != nil && !m.{{.GoName}}.Equal(m.{{.GoName}}.Truncate({{.Scale}})) {
+		errors[Columns.{{$model.GoName}}.{{.GoName}}] = ErrScale
+	}
 	{{end}}
 	{{end}}
-
+	{{range .Checks}}
+	if {{.Condition}} {
+		errors[Columns.{{$model.GoName}}.{{.GoName}}] = {{.Error}}
+	}
+	{{end}}{{if .HasSkipped}}
+	// check constraints not translated to go code:{{range .Skipped}}
+	//   {{.}}{{end}}
+	{{end}}
 	return errors, len(errors) == 0
 }
-{{end}}
+{{range .Patterns}}
+var {{.Name}} = regexp.MustCompile({{.Value}})
+{{end}}{{end}}
 `
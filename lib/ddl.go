@@ -0,0 +1,1539 @@
+package genna
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+// source provides raw information about tables, relations and columns
+type source interface {
+	Tables(selected []string, views bool) ([]table, error)
+	Relations(tables []table) ([]relation, error)
+	Columns(tables []table) ([]column, error)
+	Checks(tables []table) ([]check, error)
+	Indexes(tables []table) ([]index, error)
+	Composites() ([]attribute, error)
+}
+
+// ddl is a source which reads schema from sql DDL file instead of live database
+// views are not supported as columns could not be read without executing a query
+type ddl struct {
+	tables []*ddlTable
+	index  map[string]*ddlTable
+
+	enums      map[string][]string
+	domains    map[string]*ddlDomain
+	composites map[string]*ddlComposite
+}
+
+type ddlTable struct {
+	schema string
+	name   string
+
+	columns []*ddlColumn
+	// attnum is a number of last added column, numbers of dropped columns are not reused as in database
+	attnum int
+
+	pk      []string
+	pkName  string
+	fks     []ddlForeignKey
+	checks  []ddlCheck
+	indexes []ddlIndex
+
+	comment string
+}
+
+type ddlColumn struct {
+	name string
+	num  int
+
+	typeSchema string
+	typeName   string
+
+	dims   int
+	len    int
+	serial bool
+
+	precision int
+	scale     int
+
+	notNull bool
+	def     string
+	hasDef  bool
+
+	comment string
+}
+
+// ddlDomain is a domain type, base keeps base type the same way as column does
+type ddlDomain struct {
+	schema string
+	name   string
+
+	base    ddlColumn
+	notNull bool
+	checks  []ddlCheck
+}
+
+// ddlComposite is a composite type, attributes are parsed the same way as columns
+type ddlComposite struct {
+	schema string
+	name   string
+
+	attributes []*ddlColumn
+}
+
+type ddlCheck struct {
+	name       string
+	expression string
+
+	// names are identifiers used in expression, columns are found among them
+	names []string
+}
+
+type ddlIndex struct {
+	name        string
+	columns     []string
+	expressions bool
+	unique      bool
+	constraint  bool
+	method      string
+	predicate   string
+}
+
+type ddlForeignKey struct {
+	name          string
+	columns       []string
+	targetSchema  string
+	targetTable   string
+	targetColumns []string
+}
+
+// newDDL reads and parses DDL file
+func newDDL(filename string) (*ddl, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDDL(string(content))
+}
+
+// parseDDL parses sql source, statements not affecting tables are ignored
+func parseDDL(src string) (*ddl, error) {
+	tokens, err := Lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("reading sql error: %w", err)
+	}
+
+	d := &ddl{
+		index:      map[string]*ddlTable{},
+		enums:      map[string][]string{},
+		domains:    map[string]*ddlDomain{},
+		composites: map[string]*ddlComposite{},
+	}
+
+	for _, statement := range splitTokens(tokens, ";") {
+		if err := d.statement(&parser{tokens: statement, src: src}); err != nil {
+			text := src[statement[0].start:statement[len(statement)-1].end]
+			return nil, fmt.Errorf("parsing statement '%s' error: %w", text, err)
+		}
+	}
+
+	return d, nil
+}
+
+func (d *ddl) statement(p *parser) error {
+	switch {
+	case p.accept("create", "unique", "index"):
+		return d.createIndex(p, true)
+	case p.accept("create", "index"):
+		return d.createIndex(p, false)
+	case p.accept("create"):
+		p.accept("or", "replace")
+		switch {
+		case p.accept("type"):
+			return d.createType(p)
+		case p.accept("domain"):
+			return d.createDomain(p)
+		case p.acceptTable():
+			return d.createTable(p)
+		}
+	case p.accept("alter", "table"):
+		return d.alterTable(p)
+	case p.accept("alter", "domain"):
+		return d.alterDomain(p)
+	case p.accept("drop", "index"):
+		return d.dropIndex(p)
+	case p.accept("comment", "on"):
+		return d.comment(p)
+	}
+
+	return nil
+}
+
+func (d *ddl) createType(p *parser) error {
+	schema, name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	if !p.accept("as", "enum") {
+		if p.accept("as") && p.peek().Is("(") {
+			return d.createComposite(p, schema, name)
+		}
+
+		// range and base types are not supported
+		return nil
+	}
+
+	var values []string
+	for _, value := range splitTokens(p.group(), ",") {
+		if len(value) != 1 || value[0].Kind != TokenString {
+			return fmt.Errorf("enum value expected")
+		}
+		values = append(values, value[0].Value)
+	}
+
+	d.enums[util.Join(schema, name)] = values
+
+	return nil
+}
+
+// createComposite parses attributes of composite type
+func (d *ddl) createComposite(p *parser, schema, name string) error {
+	composite := &ddlComposite{schema: schema, name: name}
+	for _, element := range splitTokens(p.group(), ",") {
+		if len(element) == 0 {
+			continue
+		}
+
+		ep := &parser{tokens: element, src: p.src}
+		attrName, err := ep.ident()
+		if err != nil {
+			return err
+		}
+
+		// collate is the only option of attribute, it does not affect type
+		attr := &ddlColumn{name: attrName}
+		if err := attr.dataType(ep); err != nil {
+			return fmt.Errorf("attribute %s: %w", attrName, err)
+		}
+
+		composite.attributes = append(composite.attributes, attr)
+	}
+
+	d.composites[util.Join(schema, name)] = composite
+
+	return nil
+}
+
+// createDomain parses domain definition, default value is ignored as it does not affect columns
+func (d *ddl) createDomain(p *parser) error {
+	schema, name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	p.accept("as")
+
+	dom := &ddlDomain{schema: schema, name: name}
+	if err := dom.base.dataType(p); err != nil {
+		return fmt.Errorf("domain %s: %w", name, err)
+	}
+
+	constraint := ""
+	for !p.done() {
+		switch {
+		case p.accept("constraint"):
+			if constraint, err = p.ident(); err != nil {
+				return err
+			}
+		case p.accept("not", "null"):
+			dom.notNull = true
+		case p.accept("null"):
+			dom.notNull = false
+		case p.accept("default"):
+			p.expression()
+		case p.accept("check"):
+			dom.addCheck(constraint, p)
+			constraint = ""
+		case p.accept("collate"):
+			if _, _, err := p.name(); err != nil {
+				return err
+			}
+		default:
+			p.next()
+		}
+	}
+
+	d.domains[util.Join(schema, name)] = dom
+
+	return nil
+}
+
+// alterDomain parses changes of domain constraints, other actions are ignored
+func (d *ddl) alterDomain(p *parser) error {
+	schema, name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	dom, ok := d.domains[util.Join(schema, name)]
+	if !ok {
+		return fmt.Errorf("domain %s not found", util.Join(schema, name))
+	}
+
+	switch {
+	case p.accept("set", "not", "null"):
+		dom.notNull = true
+	case p.accept("drop", "not", "null"):
+		dom.notNull = false
+	case p.accept("add"):
+		constraint := ""
+		if p.accept("constraint") {
+			if constraint, err = p.ident(); err != nil {
+				return err
+			}
+		}
+		switch {
+		case p.accept("not", "null"):
+			dom.notNull = true
+		case p.accept("check"):
+			dom.addCheck(constraint, p)
+		}
+	case p.accept("drop", "constraint"):
+		p.accept("if", "exists")
+		constraint, err := p.ident()
+		if err != nil {
+			return err
+		}
+		for i, check := range dom.checks {
+			if check.name == constraint {
+				dom.checks = append(dom.checks[:i], dom.checks[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *ddl) createTable(p *parser) error {
+	p.accept("if", "not", "exists")
+
+	schema, name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	// partitions and "create table as" are not supported
+	if !p.peek().Is("(") {
+		return nil
+	}
+
+	tbl := &ddlTable{schema: schema, name: name}
+	for _, element := range splitTokens(p.group(), ",") {
+		if err := tbl.element(&parser{tokens: element, src: p.src}); err != nil {
+			return err
+		}
+	}
+
+	d.tables = append(d.tables, tbl)
+	d.index[util.Join(schema, name)] = tbl
+
+	return nil
+}
+
+func (d *ddl) alterTable(p *parser) error {
+	p.accept("if", "exists")
+	p.accept("only")
+
+	schema, name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	tbl, ok := d.index[util.Join(schema, name)]
+	if !ok {
+		return fmt.Errorf("table %s not found", util.Join(schema, name))
+	}
+
+	for _, action := range splitTokens(p.rest(), ",") {
+		if err := tbl.action(&parser{tokens: action, src: p.src}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createIndex parses index definition, expressions are kept only as a flag
+func (d *ddl) createIndex(p *parser, unique bool) error {
+	p.accept("concurrently")
+	p.accept("if", "not", "exists")
+
+	name := ""
+	if !p.peek().Is("on") {
+		var err error
+		if name, err = p.ident(); err != nil {
+			return err
+		}
+	}
+
+	if !p.accept("on") {
+		return fmt.Errorf("'on' expected")
+	}
+	p.accept("only")
+
+	schema, table, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	tbl, ok := d.index[util.Join(schema, table)]
+	if !ok {
+		return fmt.Errorf("table %s not found", util.Join(schema, table))
+	}
+
+	idx := ddlIndex{unique: unique, method: model.IndexMethodBtree}
+	if p.accept("using") {
+		if idx.method, err = p.ident(); err != nil {
+			return err
+		}
+		idx.method = strings.ToLower(idx.method)
+	}
+
+	if !p.peek().Is("(") {
+		return fmt.Errorf("'(' expected")
+	}
+
+	// postgres names index after its columns, function name is used for expressions
+	parts := []string{tbl.name}
+	for _, element := range splitTokens(p.group(), ",") {
+		switch {
+		case len(element) == 0:
+			return fmt.Errorf("index element expected")
+		case element[0].IsName() && (len(element) == 1 || !element[1].Is("(")):
+			idx.columns = append(idx.columns, element[0].Value)
+			parts = append(parts, element[0].Value)
+		case element[0].IsName():
+			idx.expressions = true
+			parts = append(parts, element[0].Value)
+		default:
+			idx.expressions = true
+			parts = append(parts, "expr")
+		}
+	}
+
+	for !p.done() {
+		switch {
+		case p.accept("where"):
+			idx.predicate = p.text(p.rest())
+		case p.peek().Is("("):
+			// include and with clauses
+			p.group()
+		default:
+			p.next()
+		}
+	}
+
+	idx.name = name
+	if idx.name == "" {
+		idx.name = strings.Join(append(parts, "idx"), "_")
+	}
+	tbl.indexes = append(tbl.indexes, idx)
+
+	return nil
+}
+
+// dropIndex removes indexes from tables, missing indexes are ignored
+func (d *ddl) dropIndex(p *parser) error {
+	p.accept("concurrently")
+	p.accept("if", "exists")
+
+	for _, item := range splitTokens(p.rest(), ",") {
+		ip := &parser{tokens: item, src: p.src}
+		schema, name, err := ip.name()
+		if err != nil {
+			return err
+		}
+
+		for _, tbl := range d.tables {
+			if tbl.schema == schema {
+				tbl.dropIndex(name, false)
+			}
+		}
+	}
+
+	return nil
+}
+
+// comment parses comment on table or column, comments on other objects are ignored
+func (d *ddl) comment(p *parser) error {
+	var target *string
+
+	switch {
+	case p.accept("table"):
+		schema, name, err := p.name()
+		if err != nil {
+			return err
+		}
+
+		tbl, ok := d.index[util.Join(schema, name)]
+		if !ok {
+			return fmt.Errorf("table %s not found", util.Join(schema, name))
+		}
+		target = &tbl.comment
+	case p.accept("column"):
+		// column name is qualified with table and optionally with schema name
+		var parts []string
+		for {
+			part, err := p.ident()
+			if err != nil {
+				return err
+			}
+			parts = append(parts, part)
+
+			if !p.accept(".") {
+				break
+			}
+		}
+
+		schema, name, column := util.PublicSchema, "", ""
+		switch len(parts) {
+		case 2:
+			name, column = parts[0], parts[1]
+		case 3:
+			schema, name, column = parts[0], parts[1], parts[2]
+		default:
+			return fmt.Errorf("column name should be qualified with table name")
+		}
+
+		tbl, ok := d.index[util.Join(schema, name)]
+		if !ok {
+			return fmt.Errorf("table %s not found", util.Join(schema, name))
+		}
+
+		col := tbl.find(column)
+		if col == nil {
+			return fmt.Errorf("column %s not found", column)
+		}
+		target = &col.comment
+	default:
+		return nil
+	}
+
+	if !p.accept("is") {
+		return fmt.Errorf("'is' expected")
+	}
+
+	switch t := p.next(); {
+	case t.Kind == TokenString:
+		*target = t.Value
+	case t.Is("null"):
+		*target = ""
+	default:
+		return fmt.Errorf("comment text expected, got '%s'", t.Value)
+	}
+
+	return nil
+}
+
+// element parses column definition or table constraint
+func (t *ddlTable) element(p *parser) error {
+	if p.isConstraint() {
+		return t.constraint(p)
+	}
+
+	if p.accept("like") {
+		return fmt.Errorf("like clause is not supported")
+	}
+
+	return t.column(p)
+}
+
+// action parses alter table action
+func (t *ddlTable) action(p *parser) error {
+	switch {
+	case p.accept("add"):
+		if p.isConstraint() {
+			return t.constraint(p)
+		}
+		p.accept("column")
+		p.accept("if", "not", "exists")
+		return t.column(p)
+	case p.accept("alter"):
+		p.accept("column")
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+
+		col := t.find(name)
+		if col == nil {
+			return fmt.Errorf("column %s not found", name)
+		}
+
+		switch {
+		case p.accept("set", "not", "null"):
+			col.notNull = true
+		case p.accept("drop", "not", "null"):
+			col.notNull = false
+		case p.accept("set", "default"):
+			col.def, col.hasDef = p.text(p.rest()), true
+		case p.accept("drop", "default"):
+			col.def, col.hasDef = "", false
+		case p.accept("add", "generated"):
+			col.hasDef = true
+		}
+	case p.accept("drop", "constraint"):
+		p.accept("if", "exists")
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+
+		for i, fk := range t.fks {
+			if fk.name == name {
+				t.fks = append(t.fks[:i], t.fks[i+1:]...)
+				break
+			}
+		}
+
+		for i, check := range t.checks {
+			if check.name == name {
+				t.checks = append(t.checks[:i], t.checks[i+1:]...)
+				break
+			}
+		}
+
+		if name == t.primaryName() {
+			t.pk, t.pkName = nil, ""
+		}
+
+		t.dropIndex(name, true)
+	case p.accept("drop"):
+		p.accept("column")
+		p.accept("if", "exists")
+		name, err := p.ident()
+		if err != nil {
+			return err
+		}
+
+		for i, col := range t.columns {
+			if col.name == name {
+				t.columns = append(t.columns[:i], t.columns[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *ddlTable) column(p *parser) error {
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+
+	col := &ddlColumn{name: name}
+	if err := col.dataType(p); err != nil {
+		return fmt.Errorf("column %s: %w", name, err)
+	}
+
+	t.attnum++
+	col.num = t.attnum
+
+	if col.serial {
+		seq := fmt.Sprintf("%s_%s_seq", t.name, name)
+		if t.schema != util.PublicSchema {
+			seq = fmt.Sprintf("%s.%s", quoteIdent(t.schema), quoteIdent(seq))
+		} else {
+			seq = quoteIdent(seq)
+		}
+
+		col.notNull = true
+		col.def, col.hasDef = fmt.Sprintf("nextval('%s'::regclass)", seq), true
+	}
+
+	constraint := ""
+	for !p.done() {
+		switch {
+		case p.accept("constraint"):
+			if constraint, err = p.ident(); err != nil {
+				return err
+			}
+		case p.accept("not", "null"):
+			col.notNull = true
+		case p.accept("null"):
+			col.notNull = false
+		case p.accept("default"):
+			col.def, col.hasDef = p.text(p.expression()), true
+		case p.accept("generated"):
+			col.hasDef = true
+			p.accept("always")
+			p.accept("by", "default")
+			p.accept("as")
+			if !p.accept("identity") {
+				p.group()
+				p.accept("stored")
+			}
+			p.group()
+		case p.accept("primary", "key"):
+			t.pk, t.pkName = []string{name}, constraint
+			constraint = ""
+		case p.accept("unique"):
+			p.accept("nulls", "not", "distinct")
+			p.accept("nulls", "distinct")
+			t.addUnique(constraint, []string{name})
+			constraint = ""
+		case p.accept("check"):
+			t.checks = append(t.checks, newDDLCheck(constraintName(constraint, t.name, name, "check"), p))
+			constraint = ""
+		case p.accept("references"):
+			fk, err := p.references(nil)
+			if err != nil {
+				return err
+			}
+			fk.name, fk.columns = constraintName(constraint, t.name, name, "fkey"), []string{name}
+			t.fks = append(t.fks, fk)
+			constraint = ""
+		case p.accept("collate"):
+			if _, _, err := p.name(); err != nil {
+				return err
+			}
+		default:
+			// deferrable, initially, etc.
+			p.next()
+		}
+	}
+
+	t.columns = append(t.columns, col)
+
+	return nil
+}
+
+// constraintName gets name of column constraint, postgres names unnamed ones as table_column_suffix
+func constraintName(name, table, column, suffix string) string {
+	if name != "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s_%s_%s", table, column, suffix)
+}
+
+func (t *ddlTable) constraint(p *parser) error {
+	name := ""
+	if p.accept("constraint") {
+		var err error
+		if name, err = p.ident(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case p.accept("primary", "key"):
+		columns, err := p.identList()
+		if err != nil {
+			return err
+		}
+		t.pk, t.pkName = columns, name
+	case p.accept("unique"):
+		p.accept("nulls", "not", "distinct")
+		p.accept("nulls", "distinct")
+
+		columns, err := p.identList()
+		if err != nil {
+			return err
+		}
+		t.addUnique(name, columns)
+	case p.accept("foreign", "key"):
+		columns, err := p.identList()
+		if err != nil {
+			return err
+		}
+
+		if !p.accept("references") {
+			return fmt.Errorf("references expected")
+		}
+
+		fk, err := p.references(columns)
+		if err != nil {
+			return err
+		}
+
+		fk.name = name
+		if fk.name == "" {
+			fk.name = fmt.Sprintf("%s_%s_fkey", t.name, strings.Join(columns, "_"))
+		}
+		t.fks = append(t.fks, fk)
+	case p.accept("check"):
+		check := newDDLCheck(name, p)
+		if check.name == "" {
+			// postgres names constraint after the first column used in it
+			check.name = t.name + "_check"
+			if columns := t.checkColumns(check); len(columns) > 0 {
+				check.name = fmt.Sprintf("%s_%s_check", t.name, columns[0])
+			}
+		}
+		t.checks = append(t.checks, check)
+	}
+
+	return nil
+}
+
+// addUnique adds index created by unique constraint
+func (t *ddlTable) addUnique(name string, columns []string) {
+	if name == "" {
+		name = fmt.Sprintf("%s_%s_key", t.name, strings.Join(columns, "_"))
+	}
+
+	t.indexes = append(t.indexes, ddlIndex{
+		name:       name,
+		columns:    columns,
+		unique:     true,
+		constraint: true,
+		method:     model.IndexMethodBtree,
+	})
+}
+
+// dropIndex removes index by name, constraint indexes could be dropped only with constraint
+func (t *ddlTable) dropIndex(name string, constraint bool) {
+	for i, idx := range t.indexes {
+		if idx.name == name && idx.constraint == constraint {
+			t.indexes = append(t.indexes[:i], t.indexes[i+1:]...)
+			return
+		}
+	}
+}
+
+// primaryName gets name of primary key constraint
+func (t *ddlTable) primaryName() string {
+	if t.pkName != "" {
+		return t.pkName
+	}
+
+	return t.name + "_pkey"
+}
+
+// addCheck adds check constraint to domain, unnamed constraints are named the same way as postgres does
+func (dom *ddlDomain) addCheck(name string, p *parser) {
+	if name == "" {
+		name = fmt.Sprintf("%s_check", dom.name)
+		for i := 1; dom.hasCheck(name); i++ {
+			name = fmt.Sprintf("%s_check%d", dom.name, i)
+		}
+	}
+
+	dom.checks = append(dom.checks, newDDLCheck(name, p))
+}
+
+func (dom *ddlDomain) hasCheck(name string) bool {
+	for _, check := range dom.checks {
+		if check.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolve gets base type of column or attribute with domain type and domains chain, other types are returned as is
+func (d *ddl) resolve(col *ddlColumn) (ddlColumn, []*ddlDomain) {
+	typ := *col
+	chain := d.domainChain(col.typeSchema, col.typeName)
+	for _, dom := range chain {
+		typ.typeSchema, typ.typeName = dom.base.typeSchema, dom.base.typeName
+		typ.len, typ.precision, typ.scale = dom.base.len, dom.base.precision, dom.base.scale
+		typ.dims += dom.base.dims
+	}
+
+	return typ, chain
+}
+
+// domainChain gets domains starting from domain with given name down to the one based on non-domain type
+func (d *ddl) domainChain(schema, name string) []*ddlDomain {
+	var chain []*ddlDomain
+
+	seen := util.NewSet()
+	for {
+		dom, ok := d.domains[util.Join(schema, name)]
+		if !ok || !seen.Add(util.Join(schema, name)) {
+			return chain
+		}
+
+		chain = append(chain, dom)
+		schema, name = dom.base.typeSchema, dom.base.typeName
+	}
+}
+
+// newDDLCheck parses condition of check constraint at current position
+func newDDLCheck(name string, p *parser) ddlCheck {
+	tokens := p.group()
+
+	check := ddlCheck{
+		name:       name,
+		expression: p.text(tokens),
+	}
+
+	for _, t := range tokens {
+		if t.IsName() {
+			check.names = append(check.names, t.Value)
+		}
+	}
+
+	return check
+}
+
+// checkColumns gets columns of table used in check constraint
+func (t *ddlTable) checkColumns(check ddlCheck) []string {
+	set := util.NewSet()
+
+	var columns []string
+	for _, name := range check.names {
+		if t.find(name) != nil && set.Add(name) {
+			columns = append(columns, name)
+		}
+	}
+
+	return columns
+}
+
+func (t *ddlTable) find(name string) *ddlColumn {
+	for _, col := range t.columns {
+		if col.name == name {
+			return col
+		}
+	}
+
+	return nil
+}
+
+func (t *ddlTable) isPK(name string) bool {
+	for _, pk := range t.pk {
+		if pk == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *ddlTable) isFK(name string) bool {
+	for _, fk := range t.fks {
+		for _, col := range fk.columns {
+			if col == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// dataType parses column type with modifiers and array dimensions
+func (c *ddlColumn) dataType(p *parser) error {
+	first := p.peek()
+	schema, name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	if first.Kind == TokenWord && schema == util.PublicSchema {
+		switch name {
+		case "double":
+			p.accept("precision")
+			name = "float8"
+		case "character", "char":
+			name = "bpchar"
+			c.len = 1
+			if p.accept("varying") {
+				name, c.len = "varchar", 0
+			}
+		case "bit":
+			if p.accept("varying") {
+				name = "varbit"
+			}
+		}
+	}
+
+	var modifiers []int
+	if p.peek().Is("(") {
+		for _, modifier := range splitTokens(p.group(), ",") {
+			if len(modifier) != 1 {
+				break
+			}
+			n, err := strconv.Atoi(modifier[0].Value)
+			if err != nil {
+				break
+			}
+			modifiers = append(modifiers, n)
+		}
+
+		if len(modifiers) > 0 {
+			c.len = modifiers[0]
+		}
+	}
+
+	if first.Kind == TokenWord && schema == util.PublicSchema {
+		switch name {
+		case "timestamp", "time":
+			if p.accept("with", "time", "zone") {
+				name += "tz"
+			}
+			p.accept("without", "time", "zone")
+		case "interval":
+			for p.acceptAny("year", "month", "day", "hour", "minute", "second", "to") {
+				// fields do not affect type
+			}
+			p.group()
+		case "float":
+			if c.len > 0 && c.len <= 24 {
+				name = "float4"
+			}
+		}
+
+		if name, c.serial = ddlType(name); name != "bpchar" && name != "varchar" && name != "varbit" && name != "bit" {
+			c.len = 0
+		}
+
+		if name == "numeric" && len(modifiers) > 0 {
+			c.precision = modifiers[0]
+			if len(modifiers) > 1 {
+				c.scale = modifiers[1]
+			}
+		}
+	}
+
+	if p.accept("array") {
+		if p.peek().Is("[") {
+			p.skipTo("]")
+		}
+		c.dims = 1
+	}
+
+	for p.peek().Is("[") {
+		p.skipTo("]")
+		c.dims++
+	}
+
+	// postgres does not keep precision of arrays
+	if c.dims > 0 {
+		c.precision, c.scale = 0, 0
+	}
+
+	c.typeSchema, c.typeName = schema, name
+
+	return nil
+}
+
+// ddlType gets postgres internal type name from sql type name
+func ddlType(name string) (string, bool) {
+	switch name {
+	case "int", "integer":
+		return "int4", false
+	case "smallint":
+		return "int2", false
+	case "bigint":
+		return "int8", false
+	case "serial", "serial4":
+		return "int4", true
+	case "smallserial", "serial2":
+		return "int2", true
+	case "bigserial", "serial8":
+		return "int8", true
+	case "real":
+		return "float4", false
+	case "float", "double precision":
+		return "float8", false
+	case "decimal":
+		return "numeric", false
+	case "boolean":
+		return "bool", false
+	}
+
+	return name, false
+}
+
+// Tables gets selected tables, views are not supported
+func (d *ddl) Tables(selected []string, _ bool) ([]table, error) {
+	var result []table
+	for _, t := range d.tables {
+		if isSelected(selected, t.schema, t.name) {
+			result = append(result, table{Schema: t.schema, Name: t.name, Kind: relKindTable})
+		}
+	}
+
+	return result, nil
+}
+
+// Relations gets relations of a selected table
+func (d *ddl) Relations(tables []table) ([]relation, error) {
+	var result []relation
+	for _, t := range tables {
+		tbl, ok := d.index[util.Join(t.Schema, t.Name)]
+		if !ok {
+			continue
+		}
+
+		for _, fk := range tbl.fks {
+			target, ok := d.index[util.Join(fk.targetSchema, fk.targetTable)]
+			if !ok {
+				return nil, fmt.Errorf("table %s referenced by %s not found", util.Join(fk.targetSchema, fk.targetTable), util.Join(t.Schema, t.Name))
+			}
+
+			targetColumns := fk.targetColumns
+			if len(targetColumns) == 0 {
+				targetColumns = target.pk
+			}
+
+			result = append(result, relation{
+				Constraint:    fk.name,
+				SourceSchema:  tbl.schema,
+				SourceTable:   tbl.name,
+				SourceColumns: fk.columns,
+				TargetSchema:  target.schema,
+				TargetTable:   target.name,
+				TargetColumns: targetColumns,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Columns gets columns of a selected tables
+func (d *ddl) Columns(tables []table) ([]column, error) {
+	var result []column
+	for _, t := range tables {
+		tbl, ok := d.index[util.Join(t.Schema, t.Name)]
+		if !ok {
+			continue
+		}
+
+		for _, col := range tbl.columns {
+			isPK := tbl.isPK(col.name)
+
+			typ, chain := d.resolve(col)
+
+			notNull := col.notNull
+			for _, dom := range chain {
+				notNull = notNull || dom.notNull
+			}
+
+			c := column{
+				Schema:     tbl.schema,
+				Table:      tbl.name,
+				Name:       col.name,
+				Position:   col.num,
+				IsNullable: !notNull && !isPK,
+				IsArray:    typ.dims > 0,
+				Dimensions: typ.dims,
+				Type:       typ.typeName,
+				Default:    col.def,
+				HasDefault: col.hasDef,
+				IsPK:       isPK,
+				IsFK:       tbl.isFK(col.name),
+				MaxLen:     typ.len,
+				Precision:  typ.precision,
+				Scale:      typ.scale,
+
+				Comment:      col.comment,
+				TableComment: tbl.comment,
+			}
+
+			if values, ok := d.enums[util.Join(typ.typeSchema, typ.typeName)]; ok && !c.IsArray {
+				c.Type = "varchar"
+				c.Values = values
+				c.EnumSchema = typ.typeSchema
+				c.EnumName = typ.typeName
+			}
+
+			if _, ok := d.composites[util.Join(typ.typeSchema, typ.typeName)]; ok {
+				c.CompositeSchema, c.CompositeName = typ.typeSchema, typ.typeName
+			}
+
+			if len(chain) > 0 && !c.IsArray {
+				c.DomainSchema, c.DomainName = col.typeSchema, col.typeName
+				// base domain constraints go first, the same order as database gives
+				for i := len(chain) - 1; i >= 0; i-- {
+					checks := append([]ddlCheck{}, chain[i].checks...)
+					sort.SliceStable(checks, func(i, j int) bool {
+						return checks[i].name < checks[j].name
+					})
+					for _, check := range checks {
+						c.DomainCheckNames = append(c.DomainCheckNames, check.name)
+						c.DomainChecks = append(c.DomainChecks, fmt.Sprintf("CHECK (%s)", check.expression))
+					}
+				}
+			}
+
+			result = append(result, c)
+		}
+	}
+
+	return result, nil
+}
+
+// Checks gets check constraints of a selected tables
+func (d *ddl) Checks(tables []table) ([]check, error) {
+	var result []check
+	for _, t := range tables {
+		tbl, ok := d.index[util.Join(t.Schema, t.Name)]
+		if !ok {
+			continue
+		}
+
+		for _, c := range tbl.checks {
+			result = append(result, check{
+				Schema:     tbl.schema,
+				Table:      tbl.name,
+				Name:       c.name,
+				Definition: fmt.Sprintf("CHECK (%s)", c.expression),
+				Columns:    tbl.checkColumns(c),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Indexes gets indexes of a selected tables, including ones created by primary key and unique constraints
+func (d *ddl) Indexes(tables []table) ([]index, error) {
+	var result []index
+	for _, t := range tables {
+		tbl, ok := d.index[util.Join(t.Schema, t.Name)]
+		if !ok {
+			continue
+		}
+
+		var indexes []index
+		if len(tbl.pk) > 0 {
+			indexes = append(indexes, index{
+				Schema:       tbl.schema,
+				Table:        tbl.name,
+				Name:         tbl.primaryName(),
+				Columns:      tbl.pk,
+				IsUnique:     true,
+				IsPrimary:    true,
+				IsConstraint: true,
+				Method:       model.IndexMethodBtree,
+			})
+		}
+
+		for _, idx := range tbl.indexes {
+			indexes = append(indexes, index{
+				Schema:         tbl.schema,
+				Table:          tbl.name,
+				Name:           idx.name,
+				Columns:        idx.columns,
+				HasExpressions: idx.expressions,
+				IsUnique:       idx.unique,
+				IsConstraint:   idx.constraint,
+				Method:         idx.method,
+				Predicate:      idx.predicate,
+			})
+		}
+
+		// the same order as database gives
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return indexes[i].Name < indexes[j].Name
+		})
+
+		result = append(result, indexes...)
+	}
+
+	return result, nil
+}
+
+// Composites gets attributes of all composite types
+func (d *ddl) Composites() ([]attribute, error) {
+	composites := make([]*ddlComposite, 0, len(d.composites))
+	for _, composite := range d.composites {
+		composites = append(composites, composite)
+	}
+
+	// the same order as database gives
+	sort.Slice(composites, func(i, j int) bool {
+		if composites[i].schema != composites[j].schema {
+			return composites[i].schema < composites[j].schema
+		}
+		return composites[i].name < composites[j].name
+	})
+
+	var result []attribute
+	for _, composite := range composites {
+		for _, attr := range composite.attributes {
+			typ, _ := d.resolve(attr)
+
+			a := attribute{
+				Schema:     composite.schema,
+				Composite:  composite.name,
+				Name:       attr.name,
+				IsArray:    typ.dims > 0,
+				Dimensions: typ.dims,
+				Type:       typ.typeName,
+				MaxLen:     typ.len,
+				Precision:  typ.precision,
+				Scale:      typ.scale,
+			}
+
+			if _, ok := d.enums[util.Join(typ.typeSchema, typ.typeName)]; ok {
+				a.Type = "varchar"
+			}
+
+			if _, ok := d.composites[util.Join(typ.typeSchema, typ.typeName)]; ok {
+				a.CompositeSchema, a.CompositeName = typ.typeSchema, typ.typeName
+			}
+
+			result = append(result, a)
+		}
+	}
+
+	return result, nil
+}
+
+// parser is a helper to walk through statement tokens
+type parser struct {
+	tokens []Token
+	pos    int
+
+	src string
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() Token {
+	if p.done() {
+		return Token{Kind: TokenSymbol}
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	t := p.peek()
+	p.pos++
+
+	return t
+}
+
+// accept consumes sequence of keywords if all of them matched
+func (p *parser) accept(words ...string) bool {
+	if p.pos+len(words) > len(p.tokens) {
+		return false
+	}
+
+	for i, word := range words {
+		if !p.tokens[p.pos+i].Is(word) {
+			return false
+		}
+	}
+
+	p.pos += len(words)
+
+	return true
+}
+
+// acceptAny consumes one of keywords if matched
+func (p *parser) acceptAny(words ...string) bool {
+	for _, word := range words {
+		if p.accept(word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acceptTable consumes table modifiers and table keyword
+func (p *parser) acceptTable() bool {
+	start := p.pos
+
+	for p.acceptAny("global", "local", "temp", "temporary", "unlogged") {
+		// modifiers do not affect generated code
+	}
+
+	if p.accept("table") {
+		return true
+	}
+
+	p.pos = start
+
+	return false
+}
+
+// skipTo consumes tokens till symbol inclusive
+func (p *parser) skipTo(symbol string) {
+	for !p.done() {
+		if p.next().Is(symbol) {
+			return
+		}
+	}
+}
+
+// isConstraint checks if table constraint starts here
+func (p *parser) isConstraint() bool {
+	t := p.peek()
+	return t.Is("constraint") || t.Is("primary") || t.Is("foreign") || t.Is("unique") || t.Is("check") || t.Is("exclude")
+}
+
+// ident gets single identifier
+func (p *parser) ident() (string, error) {
+	t := p.next()
+	if !t.IsName() {
+		return "", fmt.Errorf("identifier expected, got '%s'", t.Value)
+	}
+
+	return t.Value, nil
+}
+
+// name gets schema qualified name, public schema is used if not set
+func (p *parser) name() (string, string, error) {
+	name, err := p.ident()
+	if err != nil {
+		return "", "", err
+	}
+
+	if !p.accept(".") {
+		return util.PublicSchema, name, nil
+	}
+
+	schema := name
+	if name, err = p.ident(); err != nil {
+		return "", "", err
+	}
+
+	return schema, name, nil
+}
+
+// identList gets list of identifiers in parentheses
+func (p *parser) identList() ([]string, error) {
+	if !p.peek().Is("(") {
+		return nil, fmt.Errorf("'(' expected")
+	}
+
+	var result []string
+	for _, item := range splitTokens(p.group(), ",") {
+		if len(item) != 1 || !item[0].IsName() {
+			return nil, fmt.Errorf("identifier expected")
+		}
+		result = append(result, item[0].Value)
+	}
+
+	return result, nil
+}
+
+// references parses target of foreign key
+func (p *parser) references(columns []string) (ddlForeignKey, error) {
+	schema, name, err := p.name()
+	if err != nil {
+		return ddlForeignKey{}, err
+	}
+
+	fk := ddlForeignKey{
+		columns:      columns,
+		targetSchema: schema,
+		targetTable:  name,
+	}
+
+	if p.peek().Is("(") {
+		if fk.targetColumns, err = p.identList(); err != nil {
+			return ddlForeignKey{}, err
+		}
+	}
+
+	for p.accept("match") || p.accept("on") {
+		p.next()
+		switch {
+		case p.accept("no", "action"), p.accept("set", "null"), p.accept("set", "default"):
+			if p.peek().Is("(") {
+				p.group()
+			}
+		case p.accept("cascade"), p.accept("restrict"):
+		}
+	}
+
+	return fk, nil
+}
+
+// group gets tokens inside parentheses at current position
+func (p *parser) group() []Token {
+	if !p.peek().Is("(") {
+		return nil
+	}
+
+	start := p.pos + 1
+	depth := 0
+	for !p.done() {
+		t := p.next()
+		switch {
+		case t.Is("("):
+			depth++
+		case t.Is(")"):
+			depth--
+			if depth == 0 {
+				return p.tokens[start : p.pos-1]
+			}
+		}
+	}
+
+	return p.tokens[start:]
+}
+
+// expression gets tokens till next column constraint
+func (p *parser) expression() []Token {
+	start := p.pos
+	for !p.done() {
+		t := p.peek()
+		if p.pos > start && (t.Is("constraint") || t.Is("not") || t.Is("null") || t.Is("primary") ||
+			t.Is("unique") || t.Is("check") || t.Is("references") || t.Is("collate") || t.Is("generated") || t.Is("default")) {
+			break
+		}
+
+		if t.Is("(") {
+			p.group()
+		} else {
+			p.next()
+		}
+	}
+
+	return p.tokens[start:p.pos]
+}
+
+// rest gets all remaining tokens
+func (p *parser) rest() []Token {
+	start := p.pos
+	p.pos = len(p.tokens)
+
+	return p.tokens[start:]
+}
+
+// text gets source text of tokens
+func (p *parser) text(tokens []Token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	return p.src[tokens[0].start:tokens[len(tokens)-1].end]
+}
+
+// quoteIdent quotes identifier the same way postgres does
+func quoteIdent(s string) string {
+	for i := 0; i < len(s); i++ {
+		if !(util.IsLower(s[i]) || isDigit(s[i]) || s[i] == '_') {
+			return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+		}
+	}
+
+	return s
+}
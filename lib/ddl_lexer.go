@@ -0,0 +1,256 @@
+package genna
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TokenKind is a kind of sql token
+type TokenKind int
+
+const (
+	// TokenWord is a keyword or unquoted identifier, value is lower cased
+	TokenWord TokenKind = iota
+	// TokenIdent is a quoted identifier
+	TokenIdent
+	// TokenString is a string literal, value is unquoted
+	TokenString
+	// TokenNumber is a numeric literal
+	TokenNumber
+	// TokenSymbol is an operator or punctuation
+	TokenSymbol
+)
+
+// Token is a lexeme of sql source
+type Token struct {
+	Kind  TokenKind
+	Value string
+
+	// position of token in source
+	start, end int
+}
+
+// Is checks if token is a keyword or symbol
+func (t Token) Is(value string) bool {
+	return (t.Kind == TokenWord || t.Kind == TokenSymbol) && t.Value == value
+}
+
+// IsName checks if token can be used as identifier
+func (t Token) IsName() bool {
+	return t.Kind == TokenWord || t.Kind == TokenIdent
+}
+
+// Lex splits sql source into tokens skipping whitespaces and comments
+func Lex(src string) ([]Token, error) {
+	var tokens []Token
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		start := i
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+			continue
+		case strings.HasPrefix(src[i:], "--"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case strings.HasPrefix(src[i:], "/*"):
+			depth := 0
+			for i < len(src) {
+				if strings.HasPrefix(src[i:], "/*") {
+					depth++
+					i += 2
+				} else if strings.HasPrefix(src[i:], "*/") {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+			if depth != 0 {
+				return nil, fmt.Errorf("unterminated comment at %d", start)
+			}
+			continue
+		case c == '\'' || ((c == 'e' || c == 'E') && i+1 < len(src) && src[i+1] == '\''):
+			escapes := c != '\''
+			if escapes {
+				i++
+			}
+			value, end, err := lexQuoted(src, i, '\'', escapes)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, Token{Kind: TokenString, Value: value, start: start, end: end})
+			i = end
+		case c == '"':
+			value, end, err := lexQuoted(src, i, '"', false)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, Token{Kind: TokenIdent, Value: value, start: start, end: end})
+			i = end
+		case c == '$' && dollarTag(src[i:]) != "":
+			tag := dollarTag(src[i:])
+			body := i + len(tag)
+			end := strings.Index(src[body:], tag)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated dollar-quoted string at %d", start)
+			}
+			i = body + end + len(tag)
+			tokens = append(tokens, Token{Kind: TokenString, Value: src[body : body+end], start: start, end: i})
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, Token{Kind: TokenNumber, Value: src[start:i], start: start, end: i})
+		case isWordStart(c):
+			for i < len(src) && isWordPart(src[i]) {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: TokenWord, Value: strings.ToLower(src[start:i]), start: start, end: i})
+		case strings.HasPrefix(src[i:], "::"):
+			i += 2
+			tokens = append(tokens, Token{Kind: TokenSymbol, Value: "::", start: start, end: i})
+		case isOperator(c):
+			i = operatorEnd(src, i)
+			tokens = append(tokens, Token{Kind: TokenSymbol, Value: src[start:i], start: start, end: i})
+		default:
+			i++
+			tokens = append(tokens, Token{Kind: TokenSymbol, Value: string(c), start: start, end: i})
+		}
+	}
+
+	return tokens, nil
+}
+
+// lexQuoted reads quoted literal starting at i, doubled quote is an escaped quote
+func lexQuoted(src string, i int, quote byte, escapes bool) (string, int, error) {
+	var value strings.Builder
+
+	for j := i + 1; j < len(src); j++ {
+		switch {
+		case escapes && src[j] == '\\' && j+1 < len(src):
+			j++
+			value.WriteByte(unescape(src[j]))
+		case src[j] == quote && j+1 < len(src) && src[j+1] == quote:
+			j++
+			value.WriteByte(quote)
+		case src[j] == quote:
+			return value.String(), j + 1, nil
+		default:
+			value.WriteByte(src[j])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted literal at %d", i)
+}
+
+// unescape gets character of backslash escape sequence in escape string, e.g. E'\n'
+func unescape(c byte) byte {
+	switch c {
+	case 'b':
+		return '\b'
+	case 'f':
+		return '\f'
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	}
+
+	return c
+}
+
+// operatorEnd gets end of operator starting at i as postgres does:
+// operator is the longest run of operator characters not containing comment start,
+// trailing + and - are not part of it unless it contains one of ~!@#%^&|`?, e.g. >=-1 is >= and -1
+func operatorEnd(src string, i int) int {
+	start := i
+	for i < len(src) && isOperator(src[i]) && (i == start || !strings.HasPrefix(src[i:], "--") && !strings.HasPrefix(src[i:], "/*")) {
+		i++
+	}
+
+	if !strings.ContainsAny(src[start:i], "~!@#%^&|`?") {
+		for i > start+1 && (src[i-1] == '+' || src[i-1] == '-') {
+			i--
+		}
+	}
+
+	return i
+}
+
+func isOperator(c byte) bool {
+	return strings.IndexByte("+-*/<>=~!@#%^&|`?", c) >= 0
+}
+
+// dollarTag gets opening tag of dollar-quoted string like $$ or $body$
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '$' {
+			return s[:i+1]
+		}
+		if !isWordPart(s[i]) || (i == 1 && isDigit(s[i])) {
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isWordPart(c byte) bool {
+	return isWordStart(c) || isDigit(c) || c == '$'
+}
+
+// splitTokens splits tokens by symbol on top level of parentheses
+func splitTokens(tokens []Token, separator string) [][]Token {
+	var (
+		result [][]Token
+		depth  int
+		from   int
+	)
+
+	for i, t := range tokens {
+		switch {
+		case t.Is("(") || t.Is("["):
+			depth++
+		case t.Is(")") || t.Is("]"):
+			depth--
+		case depth == 0 && t.Is(separator):
+			if i > from {
+				result = append(result, tokens[from:i])
+			}
+			from = i + 1
+		}
+	}
+
+	if from < len(tokens) {
+		result = append(result, tokens[from:])
+	}
+
+	return result
+}
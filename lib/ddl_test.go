@@ -0,0 +1,562 @@
+package genna
+
+import (
+	"path"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func prepareDDL() (*ddl, error) {
+	_, filename, _, _ := runtime.Caller(0)
+	return newDDL(path.Join(path.Dir(filename), "..", "test_db.sql"))
+}
+
+func Test_lex(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "Should lex words in lower case",
+			src:  "CREATE Table users",
+			want: []string{"create", "table", "users"},
+		},
+		{
+			name: "Should keep quoted identifiers",
+			src:  `"userId" "a""b"`,
+			want: []string{"userId", `a"b`},
+		},
+		{
+			name: "Should skip comments",
+			src:  "a -- comment\n /* multi /* nested */ line */ b",
+			want: []string{"a", "b"},
+		},
+		{
+			name: "Should lex strings",
+			src:  `'it''s' E'\'' $$body;$$ $tag$x$tag$`,
+			want: []string{"it's", "'", "body;", "x"},
+		},
+		{
+			name: "Should lex symbols and numbers",
+			src:  "'1'::int[] = 1.5e3",
+			want: []string{"1", "::", "int", "[", "]", "=", "1.5e3"},
+		},
+		{
+			name: "Should lex operators",
+			src:  "a>=-1 b!~*c d<>e f*-g h--comment",
+			want: []string{"a", ">=", "-", "1", "b", "!~*", "c", "d", "<>", "e", "f", "*", "-", "g", "h"},
+		},
+		{
+			name: "Should unescape escape strings",
+			src:  `E'a\nb\tc\\' 'a\n'`,
+			want: []string{"a\nb\tc\\", `a\n`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := Lex(tt.src)
+			if err != nil {
+				t.Errorf("Lex() error = %v", err)
+				return
+			}
+
+			var got []string
+			for _, token := range tokens {
+				got = append(got, token.Value)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseDDL(t *testing.T) {
+	t.Run("Should parse column types", func(t *testing.T) {
+		d, err := parseDDL(`
+			create type status as enum ('new', 'done');
+			create table if not exists public.items (
+				id          bigserial primary key,
+				code        character varying(10) not null,
+				letter      char,
+				price       numeric(10, 2) default 0.0,
+				amount      decimal(12) not null,
+				rates       numeric(5, 2)[],
+				ratio       double precision,
+				created     timestamp(3) with time zone not null default now(),
+				period      interval day to second,
+				tags        text[][] not null,
+				grid        integer array,
+				status      status not null default 'new'::status,
+				owner       uuid constraint fk_owner references users on delete cascade,
+				"someId"    int generated by default as identity
+			);
+			create table users (id uuid primary key);
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		tables, err := d.Tables([]string{"public.items"}, false)
+		if err != nil {
+			t.Errorf("ddl.Tables() error = %v", err)
+			return
+		}
+
+		columns, err := d.Columns(tables)
+		if err != nil {
+			t.Errorf("ddl.Columns() error = %v", err)
+			return
+		}
+
+		want := []column{
+			{Schema: "public", Table: "items", Name: "id", Position: 1, Type: "int8", Default: `nextval('items_id_seq'::regclass)`, HasDefault: true, IsPK: true},
+			{Schema: "public", Table: "items", Name: "code", Position: 2, Type: "varchar", MaxLen: 10},
+			{Schema: "public", Table: "items", Name: "letter", Position: 3, Type: "bpchar", MaxLen: 1, IsNullable: true},
+			{Schema: "public", Table: "items", Name: "price", Position: 4, Type: "numeric", Default: "0.0", HasDefault: true, IsNullable: true, Precision: 10, Scale: 2},
+			{Schema: "public", Table: "items", Name: "amount", Position: 5, Type: "numeric", Precision: 12},
+			{Schema: "public", Table: "items", Name: "rates", Position: 6, Type: "numeric", IsArray: true, Dimensions: 1, IsNullable: true},
+			{Schema: "public", Table: "items", Name: "ratio", Position: 7, Type: "float8", IsNullable: true},
+			{Schema: "public", Table: "items", Name: "created", Position: 8, Type: "timestamptz", Default: "now()", HasDefault: true},
+			{Schema: "public", Table: "items", Name: "period", Position: 9, Type: "interval", IsNullable: true},
+			{Schema: "public", Table: "items", Name: "tags", Position: 10, Type: "text", IsArray: true, Dimensions: 2},
+			{Schema: "public", Table: "items", Name: "grid", Position: 11, Type: "int4", IsArray: true, Dimensions: 1, IsNullable: true},
+			{Schema: "public", Table: "items", Name: "status", Position: 12, Type: "varchar", Default: "'new'::status", HasDefault: true, Values: []string{"new", "done"}, EnumSchema: "public", EnumName: "status"},
+			{Schema: "public", Table: "items", Name: "owner", Position: 13, Type: "uuid", IsFK: true, IsNullable: true},
+			{Schema: "public", Table: "items", Name: "someId", Position: 14, Type: "int4", HasDefault: true, IsNullable: true},
+		}
+
+		if len(columns) != len(want) {
+			t.Errorf("len(ddl.Columns()) = %v, want %v", len(columns), len(want))
+			return
+		}
+
+		for i := range want {
+			if !reflect.DeepEqual(columns[i], want[i]) {
+				t.Errorf("ddl.Columns()[%d] = %+v, want %+v", i, columns[i], want[i])
+			}
+		}
+
+		relations, err := d.Relations(tables)
+		if err != nil {
+			t.Errorf("ddl.Relations() error = %v", err)
+			return
+		}
+
+		wantRelations := []relation{
+			{
+				Constraint:    "fk_owner",
+				SourceSchema:  "public",
+				SourceTable:   "items",
+				SourceColumns: []string{"owner"},
+				TargetSchema:  "public",
+				TargetTable:   "users",
+				TargetColumns: []string{"id"},
+			},
+		}
+		if !reflect.DeepEqual(relations, wantRelations) {
+			t.Errorf("ddl.Relations() = %+v, want %+v", relations, wantRelations)
+		}
+	})
+
+	t.Run("Should apply alter table", func(t *testing.T) {
+		d, err := parseDDL(`
+			CREATE TABLE public.users (id integer NOT NULL, name text, old text);
+			ALTER TABLE public.users OWNER TO genna;
+			ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+			ALTER TABLE ONLY public.users ADD CONSTRAINT users_pkey PRIMARY KEY (id), DROP COLUMN old;
+			ALTER TABLE public.users ADD COLUMN email varchar(64) NOT NULL;
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		columns, err := d.Columns([]table{{Schema: "public", Name: "users"}})
+		if err != nil {
+			t.Errorf("ddl.Columns() error = %v", err)
+			return
+		}
+
+		want := []column{
+			{Schema: "public", Table: "users", Name: "id", Position: 1, Type: "int4", Default: "nextval('public.users_id_seq'::regclass)", HasDefault: true, IsPK: true},
+			{Schema: "public", Table: "users", Name: "name", Position: 2, Type: "text", IsNullable: true},
+			{Schema: "public", Table: "users", Name: "email", Position: 4, Type: "varchar", MaxLen: 64},
+		}
+		if !reflect.DeepEqual(columns, want) {
+			t.Errorf("ddl.Columns() = %+v, want %+v", columns, want)
+		}
+	})
+
+	t.Run("Should read comments", func(t *testing.T) {
+		d, err := parseDDL(`
+			create table geo.cities (id int primary key, name text, code text);
+			comment on table geo.cities is 'Cities of the world';
+			comment on column geo.cities.name is 'Name of the city';
+			comment on column geo.cities.code is 'Old code';
+			comment on column geo.cities.code is null;
+			comment on schema geo is 'Geography';
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		columns, err := d.Columns([]table{{Schema: "geo", Name: "cities"}})
+		if err != nil {
+			t.Errorf("ddl.Columns() error = %v", err)
+			return
+		}
+
+		want := []column{
+			{Schema: "geo", Table: "cities", Name: "id", Position: 1, Type: "int4", IsPK: true, TableComment: "Cities of the world"},
+			{Schema: "geo", Table: "cities", Name: "name", Position: 2, Type: "text", IsNullable: true, Comment: "Name of the city", TableComment: "Cities of the world"},
+			{Schema: "geo", Table: "cities", Name: "code", Position: 3, Type: "text", IsNullable: true, TableComment: "Cities of the world"},
+		}
+		if !reflect.DeepEqual(columns, want) {
+			t.Errorf("ddl.Columns() = %+v, want %+v", columns, want)
+		}
+	})
+
+	t.Run("Should read checks", func(t *testing.T) {
+		d, err := parseDDL(`
+			create table items (
+				id    int primary key,
+				price numeric check (price > 0),
+				"minQty" int,
+				"maxQty" int,
+				code  text,
+				check ("minQty" <= "maxQty"),
+				constraint items_old check (code is not null)
+			);
+			alter table items add constraint items_code check (code ~ '^[A-Z]+$') not valid, drop constraint items_old;
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		checks, err := d.Checks([]table{{Schema: "public", Name: "items"}})
+		if err != nil {
+			t.Errorf("ddl.Checks() error = %v", err)
+			return
+		}
+
+		want := []check{
+			{Schema: "public", Table: "items", Name: "items_price_check", Definition: "CHECK (price > 0)", Columns: []string{"price"}},
+			{Schema: "public", Table: "items", Name: "items_minQty_check", Definition: `CHECK ("minQty" <= "maxQty")`, Columns: []string{"minQty", "maxQty"}},
+			{Schema: "public", Table: "items", Name: "items_code", Definition: "CHECK (code ~ '^[A-Z]+$')", Columns: []string{"code"}},
+		}
+		if !reflect.DeepEqual(checks, want) {
+			t.Errorf("ddl.Checks() = %+v, want %+v", checks, want)
+		}
+	})
+
+	t.Run("Should keep names of column constraints", func(t *testing.T) {
+		d, err := parseDDL(`
+			create table users (id int primary key);
+			create table orders (
+				id         int primary key,
+				qty        int constraint orders_qty_positive check (qty > 0),
+				old        int constraint orders_old_positive check (old > 0),
+				"userId"   int constraint orders_user references users (id),
+				"sellerId" int constraint orders_seller references users (id)
+			);
+			alter table orders drop constraint orders_old_positive, drop constraint orders_seller;
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		tables := []table{{Schema: "public", Name: "orders"}}
+
+		checks, err := d.Checks(tables)
+		if err != nil {
+			t.Errorf("ddl.Checks() error = %v", err)
+			return
+		}
+
+		want := []check{
+			{Schema: "public", Table: "orders", Name: "orders_qty_positive", Definition: "CHECK (qty > 0)", Columns: []string{"qty"}},
+		}
+		if !reflect.DeepEqual(checks, want) {
+			t.Errorf("ddl.Checks() = %+v, want %+v", checks, want)
+		}
+
+		relations, err := d.Relations(tables)
+		if err != nil {
+			t.Errorf("ddl.Relations() error = %v", err)
+			return
+		}
+
+		wantRelations := []relation{
+			{
+				Constraint:    "orders_user",
+				SourceSchema:  "public",
+				SourceTable:   "orders",
+				SourceColumns: []string{"userId"},
+				TargetSchema:  "public",
+				TargetTable:   "users",
+				TargetColumns: []string{"id"},
+			},
+		}
+		if !reflect.DeepEqual(relations, wantRelations) {
+			t.Errorf("ddl.Relations() = %+v, want %+v", relations, wantRelations)
+		}
+	})
+
+	t.Run("Should read indexes", func(t *testing.T) {
+		d, err := parseDDL(`
+			create table users (
+				id    int constraint users_id primary key,
+				email text unique,
+				"tenantId" int,
+				login text,
+				tags  text[],
+				unique nulls not distinct ("tenantId", login)
+			);
+			create unique index on users (lower(email));
+			create index users_tags on users using GIN (tags) where tags is not null;
+			create index users_old on users (login);
+			drop index users_old;
+			alter table users add constraint users_login unique (login), drop constraint "users_tenantId_login_key";
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		indexes, err := d.Indexes([]table{{Schema: "public", Name: "users"}})
+		if err != nil {
+			t.Errorf("ddl.Indexes() error = %v", err)
+			return
+		}
+
+		want := []index{
+			{Schema: "public", Table: "users", Name: "users_email_key", Columns: []string{"email"}, IsUnique: true, IsConstraint: true, Method: "btree"},
+			{Schema: "public", Table: "users", Name: "users_id", Columns: []string{"id"}, IsUnique: true, IsPrimary: true, IsConstraint: true, Method: "btree"},
+			{Schema: "public", Table: "users", Name: "users_login", Columns: []string{"login"}, IsUnique: true, IsConstraint: true, Method: "btree"},
+			{Schema: "public", Table: "users", Name: "users_lower_idx", HasExpressions: true, IsUnique: true, Method: "btree"},
+			{Schema: "public", Table: "users", Name: "users_tags", Columns: []string{"tags"}, Method: "gin", Predicate: "tags is not null"},
+		}
+		if !reflect.DeepEqual(indexes, want) {
+			t.Errorf("ddl.Indexes() = %+v, want %+v", indexes, want)
+		}
+	})
+
+	t.Run("Should read domains", func(t *testing.T) {
+		d, err := parseDDL(`
+			create domain code as varchar(16) check (value ~ '^[a-z]+$');
+			create domain short_code code not null constraint code_length check (length(value) >= 2);
+			create domain codes as code[];
+			create type status as enum ('new', 'done');
+			create domain task_status as status default 'new';
+			alter domain short_code add check (value <> 'none');
+			create table tasks (
+				code   short_code,
+				alias  code default 'none',
+				codes  codes,
+				status task_status
+			);
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		columns, err := d.Columns([]table{{Schema: "public", Name: "tasks"}})
+		if err != nil {
+			t.Errorf("ddl.Columns() error = %v", err)
+			return
+		}
+
+		want := []column{
+			{
+				Schema: "public", Table: "tasks", Name: "code", Position: 1, Type: "varchar", MaxLen: 16,
+				DomainSchema: "public", DomainName: "short_code",
+				DomainCheckNames: []string{"code_check", "code_length", "short_code_check"},
+				DomainChecks:     []string{"CHECK (value ~ '^[a-z]+$')", "CHECK (length(value) >= 2)", "CHECK (value <> 'none')"},
+			},
+			{
+				Schema: "public", Table: "tasks", Name: "alias", Position: 2, Type: "varchar", MaxLen: 16, IsNullable: true,
+				Default: "'none'", HasDefault: true,
+				DomainSchema: "public", DomainName: "code",
+				DomainCheckNames: []string{"code_check"},
+				DomainChecks:     []string{"CHECK (value ~ '^[a-z]+$')"},
+			},
+			{Schema: "public", Table: "tasks", Name: "codes", Position: 3, Type: "varchar", MaxLen: 16, IsNullable: true, IsArray: true, Dimensions: 1},
+			{
+				Schema: "public", Table: "tasks", Name: "status", Position: 4, Type: "varchar", IsNullable: true,
+				Values: []string{"new", "done"}, EnumSchema: "public", EnumName: "status",
+				DomainSchema: "public", DomainName: "task_status",
+			},
+		}
+		if !reflect.DeepEqual(columns, want) {
+			t.Errorf("ddl.Columns() = %+v, want %+v", columns, want)
+		}
+	})
+
+	t.Run("Should read composite types", func(t *testing.T) {
+		d, err := parseDDL(`
+			create type status as enum ('new', 'done');
+			create domain zip as varchar(10);
+			create type geo as (lat float8, lng float8);
+			create type address as (
+				street varchar(64),
+				zip    zip,
+				status status,
+				tags   text[],
+				point  geo
+			);
+			create table places (
+				address address not null,
+				history address[]
+			);
+		`)
+		if err != nil {
+			t.Errorf("parseDDL() error = %v", err)
+			return
+		}
+
+		columns, err := d.Columns([]table{{Schema: "public", Name: "places"}})
+		if err != nil {
+			t.Errorf("ddl.Columns() error = %v", err)
+			return
+		}
+
+		wantColumns := []column{
+			{Schema: "public", Table: "places", Name: "address", Position: 1, Type: "address", CompositeSchema: "public", CompositeName: "address"},
+			{
+				Schema: "public", Table: "places", Name: "history", Position: 2, Type: "address", IsNullable: true, IsArray: true, Dimensions: 1,
+				CompositeSchema: "public", CompositeName: "address",
+			},
+		}
+		if !reflect.DeepEqual(columns, wantColumns) {
+			t.Errorf("ddl.Columns() = %+v, want %+v", columns, wantColumns)
+		}
+
+		composites, err := d.Composites()
+		if err != nil {
+			t.Errorf("ddl.Composites() error = %v", err)
+			return
+		}
+
+		wantComposites := []attribute{
+			{Schema: "public", Composite: "address", Name: "street", Type: "varchar", MaxLen: 64},
+			{Schema: "public", Composite: "address", Name: "zip", Type: "varchar", MaxLen: 10},
+			{Schema: "public", Composite: "address", Name: "status", Type: "varchar"},
+			{Schema: "public", Composite: "address", Name: "tags", Type: "text", IsArray: true, Dimensions: 1},
+			{Schema: "public", Composite: "address", Name: "point", Type: "geo", CompositeSchema: "public", CompositeName: "geo"},
+			{Schema: "public", Composite: "geo", Name: "lat", Type: "float8"},
+			{Schema: "public", Composite: "geo", Name: "lng", Type: "float8"},
+		}
+		if !reflect.DeepEqual(composites, wantComposites) {
+			t.Errorf("ddl.Composites() = %+v, want %+v", composites, wantComposites)
+		}
+	})
+
+	t.Run("Should fail on comment for unknown column", func(t *testing.T) {
+		if _, err := parseDDL(`create table users (id int); comment on column users.name is 'Name'`); err == nil {
+			t.Errorf("parseDDL() error = nil, want error")
+		}
+	})
+
+	t.Run("Should fail on unknown table", func(t *testing.T) {
+		if _, err := parseDDL(`alter table users add column id int`); err == nil {
+			t.Errorf("parseDDL() error = nil, want error")
+		}
+	})
+}
+
+func Test_ddl_Tables(t *testing.T) {
+	d, err := prepareDDL()
+	if err != nil {
+		t.Errorf("prepare DDL error = %v", err)
+		return
+	}
+
+	t.Run("Should get all tables from test DDL", func(t *testing.T) {
+		tables, err := d.Tables([]string{"public.*", "geo.*"}, false)
+		if err != nil {
+			t.Errorf("get tables error = %v", err)
+			return
+		}
+
+		if ln := len(tables); ln != 3 {
+			t.Errorf("len(ddl.Tables()) = %v, want %v", ln, 3)
+			return
+		}
+	})
+
+	t.Run("Should get specific & geo tables from test DDL", func(t *testing.T) {
+		tables, err := d.Tables([]string{"public.users", "geo.*"}, false)
+		if err != nil {
+			t.Errorf("get tables error = %v", err)
+			return
+		}
+
+		if ln := len(tables); ln != 2 {
+			t.Errorf("len(ddl.Tables()) = %v, want %v", ln, 2)
+			return
+		}
+	})
+}
+
+func Test_ddl_Relations(t *testing.T) {
+	d, err := prepareDDL()
+	if err != nil {
+		t.Errorf("prepare DDL error = %v", err)
+		return
+	}
+
+	t.Run("Should get all relations from test DDL", func(t *testing.T) {
+		tables, err := d.Tables([]string{"public.*"}, false)
+		if err != nil {
+			t.Errorf("get tables error = %v", err)
+			return
+		}
+
+		relations, err := d.Relations(tables)
+		if err != nil {
+			t.Errorf("get relations error = %v", err)
+			return
+		}
+
+		if ln := len(relations); ln != 1 {
+			t.Errorf("len(ddl.Relations()) = %v, want %v", ln, 1)
+			return
+		}
+	})
+}
+
+func Test_ddl_Columns(t *testing.T) {
+	d, err := prepareDDL()
+	if err != nil {
+		t.Errorf("prepare DDL error = %v", err)
+		return
+	}
+
+	t.Run("Should get all columns from test DDL", func(t *testing.T) {
+		tables, err := d.Tables([]string{"public.*"}, false)
+		if err != nil {
+			t.Errorf("get tables error = %v", err)
+			return
+		}
+
+		columns, err := d.Columns(tables)
+		if err != nil {
+			t.Errorf("get columns error = %v", err)
+			return
+		}
+
+		if ln := len(columns); ln != 12 {
+			t.Errorf("len(ddl.Columns()) = %v, want %v", ln, 12)
+			return
+		}
+	})
+}
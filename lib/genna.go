@@ -12,10 +12,12 @@ import (
 
 // Genna is  struct should be embedded to custom generator when genna used as library
 type Genna struct {
-	url string
+	url      string
+	ddl      string
+	snapshot string
 
 	DB    orm.DB
-	Store *store
+	Store source
 
 	Logger *log.Logger
 }
@@ -28,14 +30,49 @@ func New(url string, logger *log.Logger) Genna {
 	}
 }
 
+// NewFromDDL creates Genna which reads schema from sql DDL file instead of database
+func NewFromDDL(filename string, logger *log.Logger) Genna {
+	return Genna{
+		ddl:    filename,
+		Logger: logger,
+	}
+}
+
+// NewFromSnapshot creates Genna which reads schema from snapshot file instead of database
+func NewFromSnapshot(filename string, logger *log.Logger) Genna {
+	return Genna{
+		snapshot: filename,
+		Logger:   logger,
+	}
+}
+
+// Connect connects to database or reads DDL or snapshot file if Genna created from it
 func (g *Genna) Connect() error {
-	var err error
+	if g.Store != nil {
+		return nil
+	}
 
-	if g.DB == nil {
-		if g.DB, err = newDatabase(g.url, g.Logger); err != nil {
-			return fmt.Errorf("unable to connect to DB: %w", err)
+	switch {
+	case g.ddl != "":
+		store, err := newDDL(g.ddl)
+		if err != nil {
+			return fmt.Errorf("unable to read DDL file: %w", err)
+		}
+		g.Store = store
+	case g.snapshot != "":
+		store, err := newSnapshotSource(g.snapshot)
+		if err != nil {
+			return fmt.Errorf("unable to read snapshot file: %w", err)
+		}
+		g.Store = store
+	default:
+		if g.DB == nil {
+			db, err := newDatabase(g.url, g.Logger)
+			if err != nil {
+				return fmt.Errorf("unable to connect to DB: %w", err)
+			}
+			g.DB = db
 		}
-
 		g.Store = newStore(g.DB)
 	}
 
@@ -43,12 +80,13 @@ func (g *Genna) Connect() error {
 }
 
 // Read reads database and gets entities with columns and relations
-func (g *Genna) Read(selected []string, followFK, useSQLNulls bool, goPGVer int, customTypes model.CustomTypeMapping) ([]model.Entity, error) {
+// views and materialized views are read only if views is set
+func (g *Genna) Read(selected []string, views, followFK, useSQLNulls bool, goPGVer int, customTypes model.CustomTypeMapping) ([]model.Entity, error) {
 	if err := g.Connect(); err != nil {
 		return nil, err
 	}
 
-	tables, err := g.Store.Tables(selected)
+	tables, err := g.Store.Tables(selected, views)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +121,13 @@ func (g *Genna) Read(selected []string, followFK, useSQLNulls bool, goPGVer int,
 		return nil, err
 	}
 
+	attributes, err := g.Store.Composites()
+	if err != nil {
+		return nil, err
+	}
+
+	composites := newComposites(attributes, useSQLNulls, goPGVer, customTypes)
+
 	entities := make([]model.Entity, len(tables))
 	index := map[string]int{}
 	for i, t := range tables {
@@ -92,19 +137,201 @@ func (g *Genna) Read(selected []string, followFK, useSQLNulls bool, goPGVer int,
 
 	for _, c := range columns {
 		if i, ok := index[util.Join(c.Schema, c.Table)]; ok {
-			entities[i].AddColumn(c.Column(useSQLNulls, goPGVer, customTypes))
+			column := c.Column(useSQLNulls, goPGVer, customTypes)
+			if composite, ok := composites.get(c.CompositeSchema, c.CompositeName); ok {
+				column.AddComposite(composite)
+			}
+
+			entities[i].AddColumn(column)
+			// table comment is read with columns, so tables added by following FKs get it too
+			entities[i].Comment = c.TableComment
+		}
+	}
+
+	checks, err := g.Store.Checks(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range checks {
+		if i, ok := index[util.Join(c.Schema, c.Table)]; ok {
+			entities[i].AddCheck(c.Check())
+		}
+	}
+
+	indexes, err := g.Store.Indexes(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ix := range indexes {
+		if i, ok := index[util.Join(ix.Schema, ix.Table)]; ok {
+			entities[i].AddIndex(ix.Index())
 		}
 	}
 
 	for _, r := range relations {
 		rel := r.Relation()
-		if i, ok := index[util.Join(r.SourceSchema, r.SourceTable)]; ok {
-			entities[i].AddRelation(rel)
-		}
 		if i, ok := index[util.Join(r.TargetSchema, r.TargetTable)]; ok {
 			rel.AddEntity(&entities[i])
 		}
+		if i, ok := index[util.Join(r.SourceSchema, r.SourceTable)]; ok {
+			entities[i].AddRelation(rel)
+		}
 	}
 
+	addInverseRelations(entities)
+
 	return entities, nil
 }
+
+// composites builds composite types from their attributes, nested composite types are built too
+type composites struct {
+	attributes map[string][]attribute
+
+	useSQLNulls bool
+	goPGVer     int
+	customTypes model.CustomTypeMapping
+}
+
+func newComposites(attributes []attribute, useSQLNulls bool, goPGVer int, customTypes model.CustomTypeMapping) composites {
+	c := composites{
+		attributes:  map[string][]attribute{},
+		useSQLNulls: useSQLNulls,
+		goPGVer:     goPGVer,
+		customTypes: customTypes,
+	}
+
+	for _, a := range attributes {
+		key := util.Join(a.Schema, a.Composite)
+		c.attributes[key] = append(c.attributes[key], a)
+	}
+
+	return c
+}
+
+// get gets composite type by name, false is returned for unknown types
+func (c composites) get(schema, name string) (model.Composite, bool) {
+	return c.build(schema, name, map[string]bool{})
+}
+
+// build builds composite type, path guards against types containing themselves
+func (c composites) build(schema, name string, path map[string]bool) (model.Composite, bool) {
+	key := util.Join(schema, name)
+
+	attributes, ok := c.attributes[key]
+	if name == "" || !ok || path[key] {
+		return model.Composite{}, false
+	}
+
+	path[key] = true
+	defer delete(path, key)
+
+	fields := make([]model.Column, len(attributes))
+	for i, a := range attributes {
+		fields[i] = a.Column(c.useSQLNulls, c.goPGVer, c.customTypes)
+		if composite, ok := c.build(a.CompositeSchema, a.CompositeName, path); ok {
+			fields[i].AddComposite(composite)
+		}
+	}
+
+	return model.NewComposite(schema, name, fields), true
+}
+
+// addInverseRelations adds has-many relations to entities referenced by other entities
+// and many2many relations to entities referenced by the same junction table
+func addInverseRelations(entities []model.Entity) {
+	type fk struct {
+		source   *model.Entity
+		relation model.Relation
+	}
+
+	var fks []fk
+	counts := map[[2]*model.Entity]int{}
+	for i := range entities {
+		for _, relation := range entities[i].Relations {
+			if relation.Type != model.HasOne || relation.TargetEntity == nil {
+				continue
+			}
+
+			// go-pg joins has-many relations only by primary key
+			if !relation.ReferencesPK() {
+				continue
+			}
+
+			fks = append(fks, fk{source: &entities[i], relation: relation})
+			counts[[2]*model.Entity{&entities[i], relation.TargetEntity}]++
+		}
+	}
+
+	for _, fk := range fks {
+		rel := model.NewHasManyRelation(fk.relation.FKFields, fk.source.PGSchema, fk.source.PGName)
+		rel.SetPKFields(fk.relation.PKFields)
+		rel.AddEntity(fk.source)
+
+		// several foreign keys to the same entity, e.g. senderId & receiverId
+		if counts[[2]*model.Entity{fk.source, fk.relation.TargetEntity}] > 1 {
+			rel.GoName = fk.relation.GoName + rel.GoName
+		}
+
+		fk.relation.TargetEntity.AddRelation(rel)
+	}
+
+	for i := range entities {
+		junction := &entities[i]
+
+		fks, ok := junctionRelations(junction)
+		if !ok {
+			continue
+		}
+
+		for _, pair := range [][2]model.Relation{{fks[0], fks[1]}, {fks[1], fks[0]}} {
+			source, target := pair[0].TargetEntity, pair[1].TargetEntity
+
+			rel := model.NewManyToManyRelation(pair[0].FKFields, pair[1].FKFields, junction, target.PGSchema, target.PGName)
+			rel.SetPKFields(pair[0].PKFields)
+			rel.JoinPKFields = pair[1].PKFields
+			rel.AddEntity(target)
+
+			source.AddRelation(rel)
+		}
+	}
+}
+
+// junctionRelations gets foreign keys of entity if it is a pure junction table:
+// it has exactly two foreign keys and primary key consists of their columns
+func junctionRelations(entity *model.Entity) ([]model.Relation, bool) {
+	var fks []model.Relation
+	for _, relation := range entity.Relations {
+		if relation.Type == model.HasOne {
+			fks = append(fks, relation)
+		}
+	}
+
+	if len(fks) != 2 || fks[0].TargetEntity == nil || fks[1].TargetEntity == nil {
+		return nil, false
+	}
+
+	if !fks[0].ReferencesPK() || !fks[1].ReferencesPK() {
+		return nil, false
+	}
+
+	fields := util.NewSet()
+	for _, relation := range fks {
+		for _, field := range relation.FKFields {
+			fields.Add(field)
+		}
+	}
+
+	pks := 0
+	for _, column := range entity.Columns {
+		if column.IsPK {
+			if !fields.Exists(column.PGName) {
+				return nil, false
+			}
+			pks++
+		}
+	}
+
+	return fks, pks == fields.Len()
+}
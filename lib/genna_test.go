@@ -3,7 +3,12 @@ package genna
 import (
 	"log"
 	"os"
+	"path"
+	"reflect"
+	"runtime"
 	"testing"
+
+	"github.com/dizzyfool/genna/model"
 )
 
 func prepareReq() (url string, logger *log.Logger) {
@@ -17,7 +22,7 @@ func TestGenna_Read(t *testing.T) {
 	genna := New(prepareReq())
 
 	t.Run("Should read DB", func(t *testing.T) {
-		entities, err := genna.Read([]string{"public.*"}, true, false, 9, nil)
+		entities, err := genna.Read([]string{"public.*"}, false, true, false, 9, nil)
 		if err != nil {
 			t.Errorf("Genna.Read error %v", err)
 			return
@@ -28,4 +33,138 @@ func TestGenna_Read(t *testing.T) {
 			return
 		}
 	})
+
+	t.Run("Should read DB with views", func(t *testing.T) {
+		entities, err := genna.Read([]string{"public.*", "geo.*"}, true, false, false, 9, nil)
+		if err != nil {
+			t.Errorf("Genna.Read error %v", err)
+			return
+		}
+
+		views := 0
+		for _, entity := range entities {
+			if entity.IsView() {
+				views++
+			}
+		}
+
+		if views != 2 {
+			t.Errorf("views = %v, want %v", views, 2)
+			return
+		}
+	})
+}
+
+func TestGenna_ReadDDL(t *testing.T) {
+	_, logger := prepareReq()
+	_, filename, _, _ := runtime.Caller(0)
+	genna := NewFromDDL(path.Join(path.Dir(filename), "..", "test_db.sql"), logger)
+
+	t.Run("Should read DDL", func(t *testing.T) {
+		entities, err := genna.Read([]string{"public.*"}, false, true, false, 9, nil)
+		if err != nil {
+			t.Errorf("Genna.Read error %v", err)
+			return
+		}
+
+		if ln := len(entities); ln != 3 {
+			t.Errorf("len(entities) = %v, want %v", ln, 3)
+			return
+		}
+
+		if ln := len(entities[1].Relations); ln != 1 {
+			t.Errorf("len(entities[1].Relations) = %v, want %v", ln, 1)
+			return
+		}
+	})
+
+	t.Run("Should read DDL with inverse relations", func(t *testing.T) {
+		entities, err := genna.Read([]string{"shop.*"}, false, true, false, 10, nil)
+		if err != nil {
+			t.Errorf("Genna.Read error %v", err)
+			return
+		}
+
+		relations := map[string]model.RelationType{}
+		for _, entity := range entities {
+			for _, relation := range entity.Relations {
+				relations[entity.GoName+"."+relation.GoName] = relation.Type
+			}
+		}
+
+		want := map[string]model.RelationType{
+			"User.SellerShopOrders":     model.HasMany,
+			"User.BuyerShopOrders":      model.HasMany,
+			"ShopItemTag.Item":          model.HasOne,
+			"ShopItemTag.Tag":           model.HasOne,
+			"ShopItem.ShopItemTags":     model.HasMany,
+			"ShopItem.ShopOrders":       model.HasMany,
+			"ShopItem.ShopTags":         model.ManyToMany,
+			"ShopOrder.Item":            model.HasOne,
+			"ShopOrder.Seller":          model.HasOne,
+			"ShopOrder.Buyer":           model.HasOne,
+			"ShopTag.ShopItemTags":      model.HasMany,
+			"ShopTag.ShopItems":         model.ManyToMany,
+			"ShopCustomer.ShopInvoices": model.HasMany,
+			"ShopInvoice.Customer":      model.HasOne,
+		}
+
+		if !reflect.DeepEqual(relations, want) {
+			t.Errorf("relations = %v, want %v", relations, want)
+		}
+	})
+
+	t.Run("Should read DDL with composite foreign key", func(t *testing.T) {
+		entities, err := genna.Read([]string{"shop.invoices"}, false, true, false, 10, nil)
+		if err != nil {
+			t.Errorf("Genna.Read error %v", err)
+			return
+		}
+
+		if ln := len(entities); ln != 2 {
+			t.Errorf("len(entities) = %v, want %v", ln, 2)
+			return
+		}
+
+		relation := entities[1].Relations[0]
+		if !reflect.DeepEqual(relation.FKFields, []string{"tenant_id", "customer_id"}) {
+			t.Errorf("relation.FKFields = %v, want %v", relation.FKFields, []string{"tenant_id", "customer_id"})
+		}
+		if !reflect.DeepEqual(relation.PKFields, []string{"tenant_id", "id"}) {
+			t.Errorf("relation.PKFields = %v, want %v", relation.PKFields, []string{"tenant_id", "id"})
+		}
+		if relation.GoName != "Customer" {
+			t.Errorf("relation.GoName = %v, want %v", relation.GoName, "Customer")
+		}
+	})
+
+	t.Run("Should read DDL with composite type", func(t *testing.T) {
+		entities, err := genna.Read([]string{"shop.customers"}, false, true, false, 0, nil)
+		if err != nil {
+			t.Errorf("Genna.Read error %v", err)
+			return
+		}
+
+		if ln := len(entities); ln != 1 {
+			t.Errorf("len(entities) = %v, want %v", ln, 1)
+			return
+		}
+
+		column := entities[0].Columns[3]
+		if column.Composite == nil {
+			t.Errorf("column.Composite = nil, want composite")
+			return
+		}
+		if column.Composite.GoName != "ShopAddress" {
+			t.Errorf("column.Composite.GoName = %v, want %v", column.Composite.GoName, "ShopAddress")
+		}
+
+		var fields []string
+		for _, field := range column.Composite.Fields {
+			fields = append(fields, field.GoName+" "+field.Type)
+		}
+		if want := []string{"Street string", "City string", "Zip string"}; !reflect.DeepEqual(fields, want) {
+			t.Errorf("column.Composite.Fields = %v, want %v", fields, want)
+		}
+	})
 }
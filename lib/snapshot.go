@@ -0,0 +1,567 @@
+package genna
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/dizzyfool/genna/model"
+	"github.com/dizzyfool/genna/util"
+)
+
+// SnapshotVersion is a current version of snapshot file format
+const SnapshotVersion = 1
+
+// Snapshot is a serializable state of database schema
+type Snapshot struct {
+	Version    int                 `json:"version"`
+	Entities   []SnapshotEntity    `json:"entities"`
+	Composites []SnapshotComposite `json:"composites,omitempty"`
+}
+
+// SnapshotEntity stores table info
+type SnapshotEntity struct {
+	Schema string           `json:"schema"`
+	Name   string           `json:"name"`
+	Kind   model.EntityKind `json:"kind"`
+
+	Comment string `json:"comment,omitempty"`
+
+	Columns   []SnapshotColumn   `json:"columns"`
+	Relations []SnapshotRelation `json:"relations,omitempty"`
+	Checks    []SnapshotCheck    `json:"checks,omitempty"`
+	Indexes   []SnapshotIndex    `json:"indexes,omitempty"`
+}
+
+// SnapshotColumn stores column info
+type SnapshotColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"hasDefault,omitempty"`
+	Nullable   bool   `json:"nullable,omitempty"`
+
+	IsArray    bool `json:"array,omitempty"`
+	Dimensions int  `json:"dimensions,omitempty"`
+
+	// Position is a number of column in table, numbers of dropped columns are not reused
+	Position int `json:"position,omitempty"`
+
+	IsPK bool `json:"pk,omitempty"`
+	IsFK bool `json:"fk,omitempty"`
+
+	MaxLen int      `json:"maxLen,omitempty"`
+	Values []string `json:"values,omitempty"`
+	Enum   string   `json:"enum,omitempty"`
+
+	Domain       string          `json:"domain,omitempty"`
+	DomainChecks []SnapshotCheck `json:"domainChecks,omitempty"`
+
+	Composite string `json:"composite,omitempty"`
+
+	Precision int `json:"precision,omitempty"`
+	Scale     int `json:"scale,omitempty"`
+
+	Comment string `json:"comment,omitempty"`
+}
+
+// SnapshotComposite stores composite type info, only types used by columns are stored
+type SnapshotComposite struct {
+	Schema     string              `json:"schema"`
+	Name       string              `json:"name"`
+	Attributes []SnapshotAttribute `json:"attributes"`
+}
+
+// SnapshotAttribute stores attribute of composite type info
+type SnapshotAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	IsArray    bool `json:"array,omitempty"`
+	Dimensions int  `json:"dimensions,omitempty"`
+
+	MaxLen    int `json:"maxLen,omitempty"`
+	Precision int `json:"precision,omitempty"`
+	Scale     int `json:"scale,omitempty"`
+
+	Composite string `json:"composite,omitempty"`
+}
+
+// SnapshotRelation stores foreign key info
+type SnapshotRelation struct {
+	Columns       []string `json:"columns"`
+	TargetSchema  string   `json:"targetSchema"`
+	TargetTable   string   `json:"targetTable"`
+	TargetColumns []string `json:"targetColumns,omitempty"`
+}
+
+// SnapshotCheck stores check constraint info
+type SnapshotCheck struct {
+	Name       string   `json:"name"`
+	Expression string   `json:"expression"`
+	Columns    []string `json:"columns,omitempty"`
+}
+
+// SnapshotIndex stores index info
+type SnapshotIndex struct {
+	Name           string   `json:"name"`
+	Columns        []string `json:"columns,omitempty"`
+	HasExpressions bool     `json:"expressions,omitempty"`
+	IsUnique       bool     `json:"unique,omitempty"`
+	IsPrimary      bool     `json:"primary,omitempty"`
+	IsConstraint   bool     `json:"constraint,omitempty"`
+	Method         string   `json:"method"`
+	Predicate      string   `json:"predicate,omitempty"`
+}
+
+// NewSnapshot creates snapshot from entities
+func NewSnapshot(entities []model.Entity) Snapshot {
+	snapshot := Snapshot{
+		Version:  SnapshotVersion,
+		Entities: make([]SnapshotEntity, len(entities)),
+	}
+
+	composites := util.NewSet()
+
+	for i, entity := range entities {
+		se := SnapshotEntity{
+			Schema:  entity.PGSchema,
+			Name:    entity.PGName,
+			Kind:    entity.Kind,
+			Comment: entity.Comment,
+			Columns: make([]SnapshotColumn, len(entity.Columns)),
+		}
+
+		for j, column := range entity.Columns {
+			enum := ""
+			if column.Enum != nil {
+				enum = util.Join(column.Enum.PGSchema, column.Enum.PGName)
+			}
+
+			composite := ""
+			if column.Composite != nil {
+				composite = util.Join(column.Composite.PGSchema, column.Composite.PGName)
+				snapshot.addComposite(*column.Composite, composites)
+			}
+
+			var (
+				domain       string
+				domainChecks []SnapshotCheck
+			)
+			if column.Domain != nil {
+				domain = util.Join(column.Domain.PGSchema, column.Domain.PGName)
+				for _, check := range column.Domain.Checks {
+					domainChecks = append(domainChecks, SnapshotCheck{
+						Name:       check.Name,
+						Expression: check.Expression,
+					})
+				}
+			}
+
+			se.Columns[j] = SnapshotColumn{
+				Name:         column.PGName,
+				Type:         column.PGType,
+				Default:      column.Default,
+				HasDefault:   column.HasDefault,
+				Nullable:     column.Nullable,
+				IsArray:      column.IsArray,
+				Dimensions:   column.Dimensions,
+				Position:     column.Position,
+				IsPK:         column.IsPK,
+				IsFK:         column.IsFK,
+				MaxLen:       column.MaxLen,
+				Values:       column.Values,
+				Enum:         enum,
+				Domain:       domain,
+				DomainChecks: domainChecks,
+				Composite:    composite,
+				Precision:    column.Precision,
+				Scale:        column.Scale,
+				Comment:      column.Comment,
+			}
+		}
+
+		for _, relation := range entity.Relations {
+			// inverse relations are restored while reading
+			if relation.Type != model.HasOne {
+				continue
+			}
+
+			se.Relations = append(se.Relations, SnapshotRelation{
+				Columns:       relation.FKFields,
+				TargetSchema:  relation.TargetPGSchema,
+				TargetTable:   relation.TargetPGName,
+				TargetColumns: relation.PKFields,
+			})
+		}
+
+		for _, check := range entity.Checks {
+			se.Checks = append(se.Checks, SnapshotCheck{
+				Name:       check.Name,
+				Expression: check.Expression,
+				Columns:    check.Columns,
+			})
+		}
+
+		for _, index := range entity.Indexes {
+			se.Indexes = append(se.Indexes, SnapshotIndex{
+				Name:           index.Name,
+				Columns:        index.Columns,
+				HasExpressions: index.HasExpressions,
+				IsUnique:       index.IsUnique,
+				IsPrimary:      index.IsPrimary,
+				IsConstraint:   index.IsConstraint,
+				Method:         index.Method,
+				Predicate:      index.Predicate,
+			})
+		}
+
+		snapshot.Entities[i] = se
+	}
+
+	// the same order as database gives
+	sort.Slice(snapshot.Composites, func(i, j int) bool {
+		if snapshot.Composites[i].Schema != snapshot.Composites[j].Schema {
+			return snapshot.Composites[i].Schema < snapshot.Composites[j].Schema
+		}
+		return snapshot.Composites[i].Name < snapshot.Composites[j].Name
+	})
+
+	return snapshot
+}
+
+// addComposite adds composite type and composite types of its attributes to snapshot once
+func (s *Snapshot) addComposite(composite model.Composite, added util.Set) {
+	if !added.Add(util.Join(composite.PGSchema, composite.PGName)) {
+		return
+	}
+
+	sc := SnapshotComposite{
+		Schema:     composite.PGSchema,
+		Name:       composite.PGName,
+		Attributes: make([]SnapshotAttribute, len(composite.Fields)),
+	}
+
+	for i, field := range composite.Fields {
+		sc.Attributes[i] = SnapshotAttribute{
+			Name:       field.PGName,
+			Type:       field.PGType,
+			IsArray:    field.IsArray,
+			Dimensions: field.Dimensions,
+			MaxLen:     field.MaxLen,
+			Precision:  field.Precision,
+			Scale:      field.Scale,
+		}
+
+		if field.Composite != nil {
+			sc.Attributes[i].Composite = util.Join(field.Composite.PGSchema, field.Composite.PGName)
+			s.addComposite(*field.Composite, added)
+		}
+	}
+
+	s.Composites = append(s.Composites, sc)
+}
+
+// ReadSnapshot reads snapshot from json file
+func ReadSnapshot(filename string) (Snapshot, error) {
+	var snapshot Snapshot
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return snapshot, err
+	}
+
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("parsing snapshot error: %w", err)
+	}
+
+	if snapshot.Version != SnapshotVersion {
+		return snapshot, fmt.Errorf("snapshot version %d not supported, expected %d", snapshot.Version, SnapshotVersion)
+	}
+
+	return snapshot, nil
+}
+
+// Marshal gets json content of snapshot file
+func (s Snapshot) Marshal() ([]byte, error) {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+// Save saves snapshot to json file
+func (s Snapshot) Save(filename string) error {
+	content, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+
+	file, err := util.File(filename)
+	if err != nil {
+		return fmt.Errorf("open snapshot file error: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(content); err != nil {
+		return fmt.Errorf("writing snapshot file error: %w", err)
+	}
+
+	return nil
+}
+
+// snapshotSource is a source which reads schema from snapshot
+type snapshotSource struct {
+	tables    []table
+	relations []relation
+	columns   []column
+	checks    []check
+	indexes   []index
+
+	attributes []attribute
+}
+
+func newSnapshotSource(filename string) (*snapshotSource, error) {
+	snapshot, err := ReadSnapshot(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &snapshotSource{}
+	for _, entity := range snapshot.Entities {
+		kind := relKindTable
+		switch entity.Kind {
+		case model.KindView:
+			kind = relKindView
+		case model.KindMaterializedView:
+			kind = relKindMaterializedView
+		}
+
+		s.tables = append(s.tables, table{
+			Schema: entity.Schema,
+			Name:   entity.Name,
+			Kind:   kind,
+		})
+
+		for _, c := range entity.Columns {
+			var enumSchema, enumName string
+			if c.Enum != "" {
+				enumSchema, enumName = util.Split(c.Enum)
+			}
+
+			var compositeSchema, compositeName string
+			if c.Composite != "" {
+				compositeSchema, compositeName = util.Split(c.Composite)
+			}
+
+			var (
+				domainSchema, domainName string
+				domainCheckNames         []string
+				domainChecks             []string
+			)
+			if c.Domain != "" {
+				domainSchema, domainName = util.Split(c.Domain)
+				for _, check := range c.DomainChecks {
+					domainCheckNames = append(domainCheckNames, check.Name)
+					domainChecks = append(domainChecks, fmt.Sprintf("CHECK (%s)", check.Expression))
+				}
+			}
+
+			s.columns = append(s.columns, column{
+				Schema:     entity.Schema,
+				Table:      entity.Name,
+				Name:       c.Name,
+				Position:   c.Position,
+				IsNullable: c.Nullable,
+				IsArray:    c.IsArray,
+				Dimensions: c.Dimensions,
+				Type:       c.Type,
+				Default:    c.Default,
+				HasDefault: c.HasDefault,
+				IsPK:       c.IsPK,
+				IsFK:       c.IsFK,
+				MaxLen:     c.MaxLen,
+				Values:     c.Values,
+				EnumSchema: enumSchema,
+				EnumName:   enumName,
+				Precision:  c.Precision,
+				Scale:      c.Scale,
+
+				DomainSchema:     domainSchema,
+				DomainName:       domainName,
+				DomainCheckNames: domainCheckNames,
+				DomainChecks:     domainChecks,
+
+				CompositeSchema: compositeSchema,
+				CompositeName:   compositeName,
+
+				Comment:      c.Comment,
+				TableComment: entity.Comment,
+			})
+		}
+
+		for _, c := range entity.Checks {
+			s.checks = append(s.checks, check{
+				Schema:     entity.Schema,
+				Table:      entity.Name,
+				Name:       c.Name,
+				Definition: fmt.Sprintf("CHECK (%s)", c.Expression),
+				Columns:    c.Columns,
+			})
+		}
+
+		for _, i := range entity.Indexes {
+			s.indexes = append(s.indexes, index{
+				Schema:         entity.Schema,
+				Table:          entity.Name,
+				Name:           i.Name,
+				Columns:        i.Columns,
+				HasExpressions: i.HasExpressions,
+				IsUnique:       i.IsUnique,
+				IsPrimary:      i.IsPrimary,
+				IsConstraint:   i.IsConstraint,
+				Method:         i.Method,
+				Predicate:      i.Predicate,
+			})
+		}
+
+		for _, r := range entity.Relations {
+			s.relations = append(s.relations, relation{
+				SourceSchema:  entity.Schema,
+				SourceTable:   entity.Name,
+				SourceColumns: r.Columns,
+				TargetSchema:  r.TargetSchema,
+				TargetTable:   r.TargetTable,
+				TargetColumns: r.TargetColumns,
+			})
+		}
+	}
+
+	for _, c := range snapshot.Composites {
+		for _, a := range c.Attributes {
+			var compositeSchema, compositeName string
+			if a.Composite != "" {
+				compositeSchema, compositeName = util.Split(a.Composite)
+			}
+
+			s.attributes = append(s.attributes, attribute{
+				Schema:     c.Schema,
+				Composite:  c.Name,
+				Name:       a.Name,
+				IsArray:    a.IsArray,
+				Dimensions: a.Dimensions,
+				Type:       a.Type,
+				MaxLen:     a.MaxLen,
+				Precision:  a.Precision,
+				Scale:      a.Scale,
+
+				CompositeSchema: compositeSchema,
+				CompositeName:   compositeName,
+			})
+		}
+	}
+
+	return s, nil
+}
+
+// Tables gets selected tables
+func (s *snapshotSource) Tables(selected []string, views bool) ([]table, error) {
+	var result []table
+	for _, t := range s.tables {
+		if !views && (t.Kind == relKindView || t.Kind == relKindMaterializedView) {
+			continue
+		}
+
+		if isSelected(selected, t.Schema, t.Name) {
+			result = append(result, t)
+		}
+	}
+
+	return result, nil
+}
+
+// Relations gets relations of a selected tables
+func (s *snapshotSource) Relations(tables []table) ([]relation, error) {
+	index := tablesIndex(tables)
+
+	var result []relation
+	for _, r := range s.relations {
+		if index.Exists(util.Join(r.SourceSchema, r.SourceTable)) {
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
+
+// Columns gets columns of a selected tables
+func (s *snapshotSource) Columns(tables []table) ([]column, error) {
+	index := tablesIndex(tables)
+
+	var result []column
+	for _, c := range s.columns {
+		if index.Exists(util.Join(c.Schema, c.Table)) {
+			result = append(result, c)
+		}
+	}
+
+	return result, nil
+}
+
+// Checks gets check constraints of a selected tables
+func (s *snapshotSource) Checks(tables []table) ([]check, error) {
+	index := tablesIndex(tables)
+
+	var result []check
+	for _, c := range s.checks {
+		if index.Exists(util.Join(c.Schema, c.Table)) {
+			result = append(result, c)
+		}
+	}
+
+	return result, nil
+}
+
+// Indexes gets indexes of a selected tables
+func (s *snapshotSource) Indexes(tables []table) ([]index, error) {
+	selected := tablesIndex(tables)
+
+	var result []index
+	for _, i := range s.indexes {
+		if selected.Exists(util.Join(i.Schema, i.Table)) {
+			result = append(result, i)
+		}
+	}
+
+	return result, nil
+}
+
+// Composites gets attributes of composite types
+func (s *snapshotSource) Composites() ([]attribute, error) {
+	return s.attributes, nil
+}
+
+// isSelected checks if table matches one of selected names
+func isSelected(selected []string, schema, name string) bool {
+	for _, s := range selected {
+		sch, tbl := util.Split(s)
+		if sch == schema && (tbl == "*" || tbl == name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tablesIndex(tables []table) util.Set {
+	index := util.NewSet()
+	for _, t := range tables {
+		index.Add(util.Join(t.Schema, t.Name))
+	}
+
+	return index
+}
@@ -0,0 +1,54 @@
+package genna
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestSnapshot_Save(t *testing.T) {
+	_, logger := prepareReq()
+	_, filename, _, _ := runtime.Caller(0)
+	output := path.Join(os.TempDir(), "snapshot_test.json")
+
+	fromDDL := NewFromDDL(path.Join(path.Dir(filename), "..", "test_db.sql"), logger)
+	entities, err := fromDDL.Read([]string{"public.*", "shop.*"}, false, true, false, 10, nil)
+	if err != nil {
+		t.Errorf("Genna.Read error %v", err)
+		return
+	}
+
+	t.Run("Should save and read snapshot", func(t *testing.T) {
+		if err := NewSnapshot(entities).Save(output); err != nil {
+			t.Errorf("Snapshot.Save error %v", err)
+			return
+		}
+
+		fromSnapshot := NewFromSnapshot(output, logger)
+		got, err := fromSnapshot.Read([]string{"public.*", "geo.*", "shop.*"}, false, false, false, 10, nil)
+		if err != nil {
+			t.Errorf("Genna.Read error %v", err)
+			return
+		}
+
+		if !reflect.DeepEqual(got, entities) {
+			t.Errorf("Genna.Read() = %v, want %v", got, entities)
+		}
+	})
+
+	t.Run("Should fail on unknown version", func(t *testing.T) {
+		snapshot := NewSnapshot(entities)
+		snapshot.Version = SnapshotVersion + 1
+
+		if err := snapshot.Save(output); err != nil {
+			t.Errorf("Snapshot.Save error %v", err)
+			return
+		}
+
+		if _, err := ReadSnapshot(output); err == nil {
+			t.Errorf("ReadSnapshot() error = nil, want error")
+		}
+	})
+}
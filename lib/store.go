@@ -18,13 +18,30 @@ func format(pattern string, values ...interface{}) string {
 	return string(formatter.FormatQuery([]byte{}, pattern, values...))
 }
 
+const (
+	relKindTable            = "r"
+	relKindPartitionedTable = "p"
+	relKindView             = "v"
+	relKindMaterializedView = "m"
+)
+
 type table struct {
 	Schema string `pg:"table_schema"`
 	Name   string `pg:"table_name"`
+	Kind   string `pg:"table_kind"`
 }
 
 func (t table) Entity() model.Entity {
-	return model.NewEntity(t.Schema, t.Name, nil, nil)
+	entity := model.NewEntity(t.Schema, t.Name, nil, nil)
+
+	switch t.Kind {
+	case relKindView:
+		entity.Kind = model.KindView
+	case relKindMaterializedView:
+		entity.Kind = model.KindMaterializedView
+	}
+
+	return entity
 }
 
 type relation struct {
@@ -38,7 +55,10 @@ type relation struct {
 }
 
 func (r relation) Relation() model.Relation {
-	return model.NewRelation(r.SourceColumns, r.TargetSchema, r.TargetTable)
+	rel := model.NewRelation(r.SourceColumns, r.TargetSchema, r.TargetTable)
+	rel.SetPKFields(r.TargetColumns)
+
+	return rel
 }
 
 func (r relation) Target() table {
@@ -54,6 +74,7 @@ type column struct {
 	Schema     string   `pg:"schema_name"`
 	Table      string   `pg:"table_name"`
 	Name       string   `pg:"column_name"`
+	Position   int      `pg:"ordinal"`
 	IsNullable bool     `pg:"nullable"`
 	IsArray    bool     `pg:"is_array"`
 	Dimensions int      `pg:"dims"`
@@ -63,11 +84,152 @@ type column struct {
 	IsPK       bool     `pg:"is_pk"`
 	IsFK       bool     `pg:"is_fk"`
 	MaxLen     int      `pg:"len"`
+	Precision  int      `pg:"precision"`
+	Scale      int      `pg:"scale"`
 	Values     []string `pg:"enum,array"`
+	EnumSchema string   `pg:"enum_schema"`
+	EnumName   string   `pg:"enum_name"`
+
+	DomainSchema     string   `pg:"domain_schema"`
+	DomainName       string   `pg:"domain_name"`
+	DomainCheckNames []string `pg:"domain_check_names,array"`
+	DomainChecks     []string `pg:"domain_checks,array"`
+
+	CompositeSchema string `pg:"composite_schema"`
+	CompositeName   string `pg:"composite_name"`
+
+	Comment      string `pg:"comment"`
+	TableComment string `pg:"table_comment"`
 }
 
 func (c column) Column(useSQLNulls bool, goPGVer int, customTypes model.CustomTypeMapping) model.Column {
-	return model.NewColumn(c.Name, c.Type, c.Default, c.HasDefault, c.IsNullable, useSQLNulls, c.IsArray, c.Dimensions, c.IsPK, c.IsFK, c.MaxLen, c.Values, goPGVer, customTypes)
+	column := model.NewColumn(c.Name, c.Type, c.Default, c.HasDefault, c.IsNullable, useSQLNulls, c.IsArray, c.Dimensions, c.IsPK, c.IsFK, c.MaxLen, c.Values, goPGVer, customTypes)
+	column.Position = c.Position
+	if c.EnumName != "" {
+		column.AddEnum(model.NewEnum(c.EnumSchema, c.EnumName, c.Values))
+	}
+	if c.DomainName != "" && !c.IsArray {
+		checks := make([]model.Check, 0, len(c.DomainChecks))
+		for i, definition := range c.DomainChecks {
+			if i < len(c.DomainCheckNames) {
+				checks = append(checks, model.NewCheck(c.DomainCheckNames[i], checkExpression(definition), nil))
+			}
+		}
+		column.AddDomain(model.NewDomain(c.DomainSchema, c.DomainName, checks))
+	}
+	column.Comment = c.Comment
+	column.Precision, column.Scale = c.Precision, c.Scale
+
+	return column
+}
+
+// attribute is an attribute of composite type
+type attribute struct {
+	Schema     string `pg:"schema_name"`
+	Composite  string `pg:"type_name"`
+	Name       string `pg:"attribute_name"`
+	IsArray    bool   `pg:"is_array"`
+	Dimensions int    `pg:"dims"`
+	Type       string `pg:"type"`
+	MaxLen     int    `pg:"len"`
+	Precision  int    `pg:"precision"`
+	Scale      int    `pg:"scale"`
+
+	// CompositeSchema and CompositeName are set if attribute is composite itself
+	CompositeSchema string `pg:"composite_schema"`
+	CompositeName   string `pg:"composite_name"`
+}
+
+// Column creates column for field of composite type, attributes are nullable but fields are not
+func (a attribute) Column(useSQLNulls bool, goPGVer int, customTypes model.CustomTypeMapping) model.Column {
+	column := model.NewColumn(a.Name, a.Type, "", false, false, useSQLNulls, a.IsArray, a.Dimensions, false, false, a.MaxLen, nil, goPGVer, customTypes)
+	column.Precision, column.Scale = a.Precision, a.Scale
+
+	return column
+}
+
+type check struct {
+	Schema     string   `pg:"schema_name"`
+	Table      string   `pg:"table_name"`
+	Name       string   `pg:"constraint_name"`
+	Definition string   `pg:"definition"`
+	Columns    []string `pg:"columns,array"`
+}
+
+func (c check) Check() model.Check {
+	return model.NewCheck(c.Name, checkExpression(c.Definition), c.Columns)
+}
+
+type index struct {
+	Schema         string   `pg:"schema_name"`
+	Table          string   `pg:"table_name"`
+	Name           string   `pg:"index_name"`
+	Columns        []string `pg:"columns,array"`
+	HasExpressions bool     `pg:"has_expressions,use_zero"`
+	IsUnique       bool     `pg:"is_unique,use_zero"`
+	IsPrimary      bool     `pg:"is_primary,use_zero"`
+	IsConstraint   bool     `pg:"is_constraint,use_zero"`
+	Method         string   `pg:"method"`
+	Predicate      string   `pg:"predicate"`
+}
+
+func (i index) Index() model.Index {
+	return model.Index{
+		Name:           i.Name,
+		Columns:        i.Columns,
+		HasExpressions: i.HasExpressions,
+		IsUnique:       i.IsUnique,
+		IsPrimary:      i.IsPrimary,
+		IsConstraint:   i.IsConstraint,
+		Method:         i.Method,
+		Predicate:      i.Predicate,
+	}
+}
+
+// checkExpression gets condition from constraint definition, e.g. CHECK ((price > 0)) NOT VALID -> (price > 0)
+func checkExpression(definition string) string {
+	expression := strings.TrimSpace(definition)
+	expression = strings.TrimSuffix(expression, " NOT VALID")
+	expression = strings.TrimSuffix(expression, " NO INHERIT")
+
+	if len(expression) > 5 && strings.EqualFold(expression[:5], "check") {
+		expression = strings.TrimSpace(expression[5:])
+	}
+
+	for isWrapped(expression) {
+		expression = strings.TrimSpace(expression[1 : len(expression)-1])
+	}
+
+	return expression
+}
+
+// isWrapped checks if whole expression is in parentheses, e.g. (a > 0) but not (a > 0) and (b > 0)
+func isWrapped(expression string) bool {
+	if !strings.HasPrefix(expression, "(") || !strings.HasSuffix(expression, ")") {
+		return false
+	}
+
+	depth, quoted := 0, byte(0)
+	for i := 0; i < len(expression); i++ {
+		c := expression[i]
+		switch {
+		case quoted != 0:
+			if c == quoted {
+				quoted = 0
+			}
+		case c == '\'' || c == '"':
+			quoted = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 && i < len(expression)-1 {
+				return false
+			}
+		}
+	}
+
+	return depth == 0
 }
 
 // Store is database helper
@@ -91,7 +253,8 @@ func (s *store) Schemas() ([]string, error) {
 	return result, nil
 }
 
-func (s *store) Tables(selected []string) ([]table, error) {
+// Tables gets selected tables, views and materialized views are included only if needed
+func (s *store) Tables(selected []string, views bool) ([]table, error) {
 	var schemas []string
 	var tables []interface{}
 
@@ -104,6 +267,11 @@ func (s *store) Tables(selected []string) ([]table, error) {
 		}
 	}
 
+	kinds := []string{relKindTable, relKindPartitionedTable}
+	if views {
+		kinds = append(kinds, relKindView, relKindMaterializedView)
+	}
+
 	var where []string
 	if len(schemas) > 0 {
 		where = append(where, format("(table_schema) in (?)", pg.In(schemas)))
@@ -115,16 +283,22 @@ func (s *store) Tables(selected []string) ([]table, error) {
 	query := `
         select 
             table_schema,
-            table_name
-        from information_schema.tables
+            table_name,
+            table_kind
+        from (
+            select
+                n.nspname       as table_schema,
+                c.relname       as table_name,
+                c.relkind::text as table_kind
+            from pg_class c
+            join pg_namespace n on n.oid = c.relnamespace
+            where c.relkind::text in (?)
+        ) t
         where 
-            table_type = 'BASE TABLE' and 
-            (
-                ` + strings.Join(where, "or \n") + `
-            )`
+            ` + strings.Join(where, "or \n")
 
 	var result []table
-	if _, err := s.db.Query(&result, query); err != nil {
+	if _, err := s.db.Query(&result, query, pg.In(kinds)); err != nil {
 		return nil, fmt.Errorf("getting tables info error: %w", err)
 	}
 
@@ -157,10 +331,10 @@ func (s *store) Relations(tables []table) ([]relation, error) {
 		       co.conname            as constraint_name,
 		       ss.nspname            as schema_name,
 		       s.relname             as table_name,
-		       array_agg(sc.attname) as columns,
+		       array_agg(sc.attname order by array_position(co.conkey, sc.attnum)) as columns,
 		       ts.nspname            as target_schema,
 		       t.relname             as target_table,
-		       array_agg(tc.attname) as target_columns
+		       array_agg(tc.attname order by array_position(co.confkey, tc.attnum)) as target_columns
 		from pg_constraint co
 		left join tables s on co.conrelid = s.oid
 		left join schemas ss on s.relnamespace = ss.oid
@@ -183,6 +357,33 @@ func (s *store) Relations(tables []table) ([]relation, error) {
 	return relations, nil
 }
 
+// resolvedDomains are query parts which resolve domains to their base types recursively
+const resolvedDomains = `
+		    domains as (
+		        select t.oid                    as domain_oid,
+		               t.typbasetype            as base_oid,
+		               t.typnotnull             as not_null,
+		               t.typtypmod              as base_typmod,
+		               array[t.oid]             as chain
+		        from pg_type t
+		        where t.typtype = 'd'
+		        union all
+		        select d.domain_oid,
+		               t.typbasetype,
+		               d.not_null or t.typnotnull,
+		               case when d.base_typmod <> -1 then d.base_typmod else t.typtypmod end,
+		               d.chain || t.oid
+		        from domains d
+		        inner join pg_type t on t.oid = d.base_oid
+		        where t.typtype = 'd'
+		    ),
+		    resolved as (
+		        select d.*
+		        from domains d
+		        inner join pg_type t on t.oid = d.base_oid
+		        where t.typtype <> 'd'
+		    ),`
+
 func (s store) Columns(tables []table) ([]column, error) {
 	ts := make([]interface{}, len(tables))
 	for i, t := range tables {
@@ -190,18 +391,23 @@ func (s store) Columns(tables []table) ([]column, error) {
 	}
 
 	query := `
-		with
+		with recursive` + resolvedDomains + `
 		    enums as (
 		        select distinct true                   as is_enum,
 		                        sch.nspname            as table_schema,
 		                        tb.relname             as table_name,
 		                        col.attname            as column_name,
-                                array_agg(e.enumlabel) as enum_values
+		                        ens.nspname            as enum_schema,
+		                        et.typname             as enum_name,
+                                array_agg(e.enumlabel order by e.enumsortorder) as enum_values
 		        from pg_class tb
 		        left join pg_namespace sch on sch.oid = tb.relnamespace
 		        left join pg_attribute col on col.attrelid = tb.oid
-		        inner join pg_enum e on e.enumtypid = col.atttypid
-				group by 1, 2, 3, 4
+		        left join resolved dom on dom.domain_oid = col.atttypid
+		        inner join pg_enum e on e.enumtypid = coalesce(dom.base_oid, col.atttypid)
+		        inner join pg_type et on et.oid = e.enumtypid
+		        inner join pg_namespace ens on ens.oid = et.typnamespace
+				group by 1, 2, 3, 4, 5, 6
 		    ),
 		    arrays as (
 		        select sch.nspname  as table_schema,
@@ -228,6 +434,71 @@ func (s store) Columns(tables []table) ([]column, error) {
 					)) as constraint_types
 				from information_schema.key_column_usage kcu
 				group by kcu.table_schema, kcu.table_name, kcu.column_name
+		    ),
+		    columns as (
+		        select sch.nspname                         as table_schema,
+		               tb.relname                          as table_name,
+		               col.attname                         as column_name,
+		               col.attnum                          as ordinal_position,
+		               not (col.attnotnull or coalesce(dom.not_null, false)) as is_nullable,
+		               (rt.typelem <> 0 and rt.typlen = -1) as is_array,
+		               case
+		               when rt.typelem <> 0 and rt.typlen = -1
+		               then coalesce(edt.typname, et.typname)
+		               else rt.typname
+		               end                                 as udt_name,
+		               pg_get_expr(def.adbin, def.adrelid) as column_default,
+		               col.attidentity in ('a', 'd')       as is_identity,
+		               information_schema._pg_char_max_length(
+		                   rt.oid,
+		                   coalesce(dom.base_typmod, col.atttypmod)
+		               )                                   as character_maximum_length,
+		               case
+		               when rt.oid = 'numeric'::regtype
+		               then information_schema._pg_numeric_precision(rt.oid, coalesce(dom.base_typmod, col.atttypmod))
+		               end                                 as numeric_precision,
+		               case
+		               when rt.oid = 'numeric'::regtype
+		               then information_schema._pg_numeric_scale(rt.oid, coalesce(dom.base_typmod, col.atttypmod))
+		               end                                 as numeric_scale,
+		               case when dom.domain_oid is not null then dns.nspname end as domain_schema,
+		               case when dom.domain_oid is not null then typ.typname end as domain_name,
+		               array(
+		                   select co.conname
+		                   from pg_constraint co
+		                   where co.contypid = any (dom.chain) and co.contype = 'c'
+		                   order by array_position(dom.chain, co.contypid) desc, co.conname
+		               )                                   as domain_check_names,
+		               array(
+		                   select pg_get_constraintdef(co.oid)
+		                   from pg_constraint co
+		                   where co.contypid = any (dom.chain) and co.contype = 'c'
+		                   order by array_position(dom.chain, co.contypid) desc, co.conname
+		               )                                   as domain_checks,
+		               case when cc.relkind = 'c' then cns.nspname end as composite_schema,
+		               case when cc.relkind = 'c' then ct.typname end as composite_name,
+		               col_description(tb.oid, col.attnum) as column_comment,
+		               obj_description(tb.oid, 'pg_class') as table_comment
+		        from pg_attribute col
+		        inner join pg_class tb on tb.oid = col.attrelid
+		        inner join pg_namespace sch on sch.oid = tb.relnamespace
+		        inner join pg_type typ on typ.oid = col.atttypid
+		        inner join pg_namespace dns on dns.oid = typ.typnamespace
+		        left join resolved dom on dom.domain_oid = typ.oid
+		        inner join pg_type rt on rt.oid = coalesce(dom.base_oid, typ.oid)
+		        left join pg_type et on et.oid = rt.typelem
+		        left join resolved edom on edom.domain_oid = et.oid
+		        left join pg_type edt on edt.oid = edom.base_oid
+		        left join pg_type ct on ct.typtype = 'c' and ct.oid = case
+		                                   when rt.typelem <> 0 and rt.typlen = -1
+		                                   then coalesce(edom.base_oid, rt.typelem)
+		                                   else rt.oid
+		                                   end
+		        left join pg_class cc on cc.oid = ct.typrelid
+		        left join pg_namespace cns on cns.oid = ct.typnamespace
+		        left join pg_attrdef def on def.adrelid = col.attrelid and def.adnum = col.attnum
+		        where col.attnum > 0
+		          and not col.attisdropped
 		    )
 		select distinct c.table_schema = 'public' as is_public,
                         c.table_schema            as schema_name,
@@ -240,25 +511,39 @@ func (s store) Columns(tables []table) ([]column, error) {
 		                else 'PRIMARY KEY'=any (i.constraint_types)
 		                end                                    as is_pk,
 		                'FOREIGN KEY'=any (i.constraint_types) as is_fk,
-		                c.is_nullable = 'YES'                  as nullable,
-		                c.data_type = 'ARRAY'                  as is_array,
-		                coalesce(a.array_dims, 0)              as dims,
+		                c.is_nullable                          as nullable,
+		                c.is_array                             as is_array,
+		                case
+		                when c.is_array
+		                then greatest(a.array_dims, 1)
+		                else 0
+		                end                                    as dims,
 		                case
 		                when e.is_enum = true
 		                then 'varchar'
 		                else ltrim(c.udt_name, '_')
 		                end                         							as type,
 		                c.column_default            							as def,
-                		(c.column_default is not null or c.is_identity = 'YES') as has_def,
+                		(c.column_default is not null or c.is_identity)         as has_def,
                         c.character_maximum_length  							as len,
-						e.enum_values 											as enum
-		from information_schema.tables t
-		left join information_schema.columns c using (table_name, table_schema)
+						coalesce(c.numeric_precision, 0)						as precision,
+						coalesce(c.numeric_scale, 0)							as scale,
+						e.enum_values 											as enum,
+						e.enum_schema 											as enum_schema,
+						e.enum_name 											as enum_name,
+						coalesce(c.domain_schema, '')							as domain_schema,
+						coalesce(c.domain_name, '')								as domain_name,
+						c.domain_check_names									as domain_check_names,
+						c.domain_checks											as domain_checks,
+						coalesce(c.composite_schema, '')						as composite_schema,
+						coalesce(c.composite_name, '')							as composite_name,
+						coalesce(c.column_comment, '')							as comment,
+						coalesce(c.table_comment, '')							as table_comment
+		from columns c
 		left join info i using (table_name, table_schema, column_name)
 		left join arrays a using (table_name, table_schema, column_name)
 		left join enums e using (table_name, table_schema, column_name)
-		where (t.table_schema, t.table_name) in (?)
-		  and t.table_type = 'BASE TABLE'
+		where (c.table_schema, c.table_name) in (?)
 		order by 1 desc, 2, 3, 5 asc, 6 desc nulls last
 	`
 
@@ -270,6 +555,153 @@ func (s store) Columns(tables []table) ([]column, error) {
 	return columns, nil
 }
 
+// Composites gets attributes of all composite types, types of tables are not included
+func (s *store) Composites() ([]attribute, error) {
+	query := `
+		with recursive` + resolvedDomains + `
+		    attributes as (
+		        select n.nspname                           as schema_name,
+		               t.typname                           as type_name,
+		               a.attname                           as attribute_name,
+		               a.attnum                            as ordinal,
+		               (rt.typelem <> 0 and rt.typlen = -1) as is_array,
+		               case
+		               when rt.typelem <> 0 and rt.typlen = -1
+		               then greatest(a.attndims, 1)
+		               else 0
+		               end                                 as dims,
+		               case when bt.typtype = 'e' then 'varchar' else bt.typname end as type,
+		               information_schema._pg_char_max_length(
+		                   rt.oid,
+		                   coalesce(dom.base_typmod, a.atttypmod)
+		               )                                   as len,
+		               case
+		               when rt.oid = 'numeric'::regtype
+		               then information_schema._pg_numeric_precision(rt.oid, coalesce(dom.base_typmod, a.atttypmod))
+		               end                                 as precision,
+		               case
+		               when rt.oid = 'numeric'::regtype
+		               then information_schema._pg_numeric_scale(rt.oid, coalesce(dom.base_typmod, a.atttypmod))
+		               end                                 as scale,
+		               case when bc.relkind = 'c' then bns.nspname end as composite_schema,
+		               case when bc.relkind = 'c' then bt.typname end as composite_name
+		        from pg_type t
+		        inner join pg_namespace n on n.oid = t.typnamespace
+		        inner join pg_class c on c.oid = t.typrelid and c.relkind = 'c'
+		        inner join pg_attribute a on a.attrelid = c.oid and a.attnum > 0 and not a.attisdropped
+		        inner join pg_type typ on typ.oid = a.atttypid
+		        left join resolved dom on dom.domain_oid = typ.oid
+		        inner join pg_type rt on rt.oid = coalesce(dom.base_oid, typ.oid)
+		        left join pg_type et on et.oid = rt.typelem and rt.typlen = -1
+		        left join resolved edom on edom.domain_oid = et.oid
+		        inner join pg_type bt on bt.oid = coalesce(edom.base_oid, et.oid, rt.oid)
+		        inner join pg_namespace bns on bns.oid = bt.typnamespace
+		        left join pg_class bc on bt.typtype = 'c' and bc.oid = bt.typrelid
+		        where t.typtype = 'c'
+		          and n.nspname not in ('pg_catalog', 'information_schema')
+		    )
+		select schema_name,
+		       type_name,
+		       attribute_name,
+		       is_array,
+		       dims,
+		       type,
+		       coalesce(len, 0)                  as len,
+		       coalesce(precision, 0)            as precision,
+		       coalesce(scale, 0)                as scale,
+		       coalesce(composite_schema, '')    as composite_schema,
+		       coalesce(composite_name, '')      as composite_name
+		from attributes
+		order by schema_name, type_name, ordinal
+	`
+
+	var attributes []attribute
+	if _, err := s.db.Query(&attributes, query); err != nil {
+		return nil, fmt.Errorf("getting composite types info error: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// Checks gets check constraints of a selected tables
+func (s *store) Checks(tables []table) ([]check, error) {
+	ts := make([]interface{}, len(tables))
+	for i, t := range tables {
+		ts[i] = []string{t.Schema, t.Name}
+	}
+
+	query := `
+		select n.nspname                    as schema_name,
+		       c.relname                    as table_name,
+		       co.conname                   as constraint_name,
+		       pg_get_constraintdef(co.oid) as definition,
+		       array(
+		           select a.attname
+		           from pg_attribute a
+		           where a.attrelid = co.conrelid and a.attnum = any (co.conkey)
+		           order by array_position(co.conkey, a.attnum)
+		       )                            as columns
+		from pg_constraint co
+		inner join pg_class c on c.oid = co.conrelid
+		inner join pg_namespace n on n.oid = c.relnamespace
+		where co.contype = 'c'
+		  and (n.nspname, c.relname) in (?)
+		order by 1, 2, 3
+	`
+
+	var checks []check
+	if _, err := s.db.Query(&checks, query, pg.InMulti(ts...)); err != nil {
+		return nil, fmt.Errorf("getting checks info error: %w", err)
+	}
+
+	return checks, nil
+}
+
+// Indexes gets indexes of a selected tables, including ones created by primary key and unique constraints
+func (s *store) Indexes(tables []table) ([]index, error) {
+	ts := make([]interface{}, len(tables))
+	for i, t := range tables {
+		ts[i] = []string{t.Schema, t.Name}
+	}
+
+	query := `
+		select n.nspname                                       as schema_name,
+		       c.relname                                       as table_name,
+		       ic.relname                                      as index_name,
+		       array(
+		           select a.attname
+		           from unnest(i.indkey::int2[]) with ordinality k(attnum, pos)
+		           inner join pg_attribute a on a.attrelid = i.indrelid and a.attnum = k.attnum
+		           where k.pos <= i.indnkeyatts
+		           order by k.pos
+		       )                                               as columns,
+		       i.indexprs is not null                          as has_expressions,
+		       i.indisunique                                   as is_unique,
+		       i.indisprimary                                  as is_primary,
+		       exists(
+		           select 1
+		           from pg_constraint co
+		           where co.conindid = i.indexrelid and co.contype = 'u'
+		       )                                               as is_constraint,
+		       am.amname                                       as method,
+		       coalesce(pg_get_expr(i.indpred, i.indrelid), '') as predicate
+		from pg_index i
+		inner join pg_class ic on ic.oid = i.indexrelid
+		inner join pg_class c on c.oid = i.indrelid
+		inner join pg_namespace n on n.oid = c.relnamespace
+		inner join pg_am am on am.oid = ic.relam
+		where (n.nspname, c.relname) in (?)
+		order by 1, 2, 3
+	`
+
+	var indexes []index
+	if _, err := s.db.Query(&indexes, query, pg.InMulti(ts...)); err != nil {
+		return nil, fmt.Errorf("getting indexes info error: %w", err)
+	}
+
+	return indexes, nil
+}
+
 // Sort sorts table by schema and name (public tables always first)
 func Sort(tables []table) []table {
 	sort.Slice(tables, func(i, j int) bool {
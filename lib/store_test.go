@@ -49,6 +49,7 @@ func Test_table_Entity(t *testing.T) {
 	type fields struct {
 		Schema string
 		Name   string
+		Kind   string
 	}
 	tests := []struct {
 		name   string
@@ -63,12 +64,39 @@ func Test_table_Entity(t *testing.T) {
 			},
 			want: model.NewEntity("public", "users", nil, nil),
 		},
+		{
+			name: "Should create entity from view",
+			fields: fields{
+				Schema: "public",
+				Name:   "active_users",
+				Kind:   relKindView,
+			},
+			want: func() model.Entity {
+				entity := model.NewEntity("public", "active_users", nil, nil)
+				entity.Kind = model.KindView
+				return entity
+			}(),
+		},
+		{
+			name: "Should create entity from materialized view",
+			fields: fields{
+				Schema: "geo",
+				Name:   "country_stats",
+				Kind:   relKindMaterializedView,
+			},
+			want: func() model.Entity {
+				entity := model.NewEntity("geo", "country_stats", nil, nil)
+				entity.Kind = model.KindMaterializedView
+				return entity
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			z := table{
 				Schema: tt.fields.Schema,
 				Name:   tt.fields.Name,
+				Kind:   tt.fields.Kind,
 			}
 			if got := z.Entity(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("table.Entity() = %v, want %v", got, tt.want)
@@ -103,7 +131,38 @@ func Test_relation_Relation(t *testing.T) {
 				TargetTable:   "locations",
 				TargetColumns: []string{"locationId"},
 			},
-			want: model.NewRelation([]string{"locationId"}, "geo", "locations"),
+			want: model.Relation{
+				Type:             model.HasOne,
+				FKFields:         []string{"locationId"},
+				PKFields:         []string{"locationId"},
+				GoName:           "Location",
+				TargetPGName:     "locations",
+				TargetPGSchema:   "geo",
+				TargetPGFullName: "geo.locations",
+				GoType:           "GeoLocation",
+			},
+		},
+		{
+			name: "Should create relation with composite key",
+			fields: fields{
+				Constraint:    "test",
+				SourceSchema:  "public",
+				SourceTable:   "orders",
+				SourceColumns: []string{"tenant_id", "customer_id"},
+				TargetSchema:  "public",
+				TargetTable:   "customers",
+				TargetColumns: []string{"tenant_id", "id"},
+			},
+			want: model.Relation{
+				Type:             model.HasOne,
+				FKFields:         []string{"tenant_id", "customer_id"},
+				PKFields:         []string{"tenant_id", "id"},
+				GoName:           "Customer",
+				TargetPGName:     "customers",
+				TargetPGSchema:   "public",
+				TargetPGFullName: "customers",
+				GoType:           "Customer",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -239,6 +298,37 @@ func Test_column_Column(t *testing.T) {
 	}
 }
 
+func Test_checkExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		want       string
+	}{
+		{
+			name:       "Should get expression from definition",
+			definition: "CHECK ((price > (0)::numeric))",
+			want:       "price > (0)::numeric",
+		},
+		{
+			name:       "Should keep parentheses of conditions",
+			definition: "CHECK (((age >= 18) AND (age <= 130))) NOT VALID",
+			want:       "(age >= 18) AND (age <= 130)",
+		},
+		{
+			name:       "Should ignore parentheses in strings",
+			definition: "CHECK ((code ~ '^(a)'::text))",
+			want:       "code ~ '^(a)'::text",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkExpression(tt.definition); got != tt.want {
+				t.Errorf("checkExpression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_store_Tables(t *testing.T) {
 	store, err := prepareStore()
 	if err != nil {
@@ -247,7 +337,7 @@ func Test_store_Tables(t *testing.T) {
 	}
 
 	t.Run("Should get all tables from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.*", "geo.*"})
+		tables, err := store.Tables([]string{"public.*", "geo.*"}, false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -260,7 +350,7 @@ func Test_store_Tables(t *testing.T) {
 	})
 
 	t.Run("Should get specific table from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.users"})
+		tables, err := store.Tables([]string{"public.users"}, false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -273,7 +363,7 @@ func Test_store_Tables(t *testing.T) {
 	})
 
 	t.Run("Should get specific & geo tables from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.users", "geo.*"})
+		tables, err := store.Tables([]string{"public.users", "geo.*"}, false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -284,6 +374,19 @@ func Test_store_Tables(t *testing.T) {
 			return
 		}
 	})
+
+	t.Run("Should get tables with views from test DB", func(t *testing.T) {
+		tables, err := store.Tables([]string{"public.*", "geo.*"}, true)
+		if err != nil {
+			t.Errorf("get tables error = %v", err)
+			return
+		}
+
+		if ln := len(tables); ln != 5 {
+			t.Errorf("len(Store.Tables()) = %v, want %v", ln, 5)
+			return
+		}
+	})
 }
 
 func Test_store_Relations(t *testing.T) {
@@ -294,7 +397,7 @@ func Test_store_Relations(t *testing.T) {
 	}
 
 	t.Run("Should get all relations from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.*"})
+		tables, err := store.Tables([]string{"public.*"}, false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
@@ -351,7 +454,7 @@ func Test_store_Columns(t *testing.T) {
 	}
 
 	t.Run("Should get all columns from test DB", func(t *testing.T) {
-		tables, err := store.Tables([]string{"public.*"})
+		tables, err := store.Tables([]string{"public.*"}, false)
 		if err != nil {
 			t.Errorf("get tables error = %v", err)
 			return
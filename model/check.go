@@ -0,0 +1,20 @@
+package model
+
+// Check stores information about check constraint
+type Check struct {
+	Name string
+
+	// Expression is a condition of constraint without CHECK keyword, e.g. price > 0
+	Expression string
+	// Columns are names of columns used in expression
+	Columns []string
+}
+
+// NewCheck creates Check from pg info
+func NewCheck(name, expression string, columns []string) Check {
+	return Check{
+		Name:       name,
+		Expression: expression,
+		Columns:    columns,
+	}
+}
@@ -21,14 +21,34 @@ type Column struct {
 	IsArray    bool
 	Dimensions int
 
+	// Position is a number of column in table (attnum), numbers of dropped columns are not reused
+	Position int
+
 	IsPK     bool
 	IsFK     bool
 	Relation *Relation
 
+	// IsUnique is set if column has its own unique constraint or index
+	IsUnique bool
+
 	Import string
 
 	MaxLen int
 	Values []string
+
+	// Precision and Scale are set for numeric columns with type modifiers, e.g. numeric(10,2)
+	Precision int
+	Scale     int
+
+	// Enum is set if column type is pg enum
+	Enum *Enum
+	// Domain is set if column type is pg domain
+	Domain *Domain
+	// Composite is set if column type or type of array elements is pg composite type
+	Composite *Composite
+
+	// Comment is a column comment set by COMMENT ON COLUMN
+	Comment string
 }
 
 // NewColumn creates Column from pg info
@@ -65,7 +85,7 @@ func NewColumn(pgName string, pgType, defaultValue string, hasDefault, nullable,
 
 	switch {
 	case column.IsArray:
-		column.Type, err = GoSlice(pgType, dims)
+		column.Type, err = GoSlice(pgType, dims, customTypes)
 	case column.Nullable:
 		column.Type, err = GoNullable(pgType, sqlNulls, customTypes)
 	default:
@@ -77,12 +97,28 @@ func NewColumn(pgName string, pgType, defaultValue string, hasDefault, nullable,
 	}
 
 	if column.Import, ok = customTypes.GoImport(pgType); !ok {
-		column.Import = GoImport(pgType, nullable, sqlNulls, goPGVer)
+		// arrays are not wrapped with sql null types
+		column.Import = GoImport(pgType, nullable && !array, sqlNulls, goPGVer)
 	}
 
 	return column
 }
 
+// AddEnum adds enum type to column
+func (c *Column) AddEnum(enum Enum) {
+	c.Enum = &enum
+}
+
+// AddDomain adds domain type to column
+func (c *Column) AddDomain(domain Domain) {
+	c.Domain = &domain
+}
+
+// AddComposite adds composite type to column
+func (c *Column) AddComposite(composite Composite) {
+	c.Composite = &composite
+}
+
 // AddRelation adds relation to column. Should be used if FK
 func (c *Column) AddRelation(relation *Relation) {
 	c.Relation = relation
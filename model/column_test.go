@@ -112,6 +112,17 @@ func TestColumn_GoType(t *testing.T) {
 			},
 			want: "pg.NullTime",
 		},
+		{
+			name: "Should generate time array type",
+			fields: fields{
+				pgType:   TypePGTimestamp,
+				array:    true,
+				dims:     1,
+				nullable: true,
+				sqlNulls: true,
+			},
+			want: "[]time.Time",
+		},
 		{
 			name: "Should generate interface for unknown type",
 			fields: fields{
@@ -132,3 +143,16 @@ func TestColumn_GoType(t *testing.T) {
 		})
 	}
 }
+
+func TestColumn_CustomTypeArray(t *testing.T) {
+	customTypes := CustomTypeMapping{}
+	customTypes.Add(TypePGUuid, "uuid.UUID", "github.com/google/uuid")
+
+	c := NewColumn("test", TypePGUuid, "", false, true, false, true, 1, false, false, 0, []string{}, 9, customTypes)
+	if c.Type != "[]uuid.UUID" {
+		t.Errorf("Column.Type = %v, want %v", c.Type, "[]uuid.UUID")
+	}
+	if c.Import != "github.com/google/uuid" {
+		t.Errorf("Column.Import = %v, want %v", c.Import, "github.com/google/uuid")
+	}
+}
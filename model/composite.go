@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/dizzyfool/genna/util"
+)
+
+// Composite stores information about pg composite type created by CREATE TYPE ... AS (...)
+type Composite struct {
+	GoName string
+
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	// Fields are attributes of composite type, they have no constraints and are not nullable
+	Fields []Column
+}
+
+// NewComposite creates Composite from pg info
+func NewComposite(schema, pgName string, fields []Column) Composite {
+	goName := util.CamelCased(util.Sanitize(pgName))
+	if schema != util.PublicSchema {
+		goName = util.CamelCased(schema) + goName
+	}
+
+	return Composite{
+		GoName: goName,
+
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.JoinF(schema, pgName),
+
+		Fields: fields,
+	}
+}
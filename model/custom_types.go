@@ -90,14 +90,21 @@ func parseCustomType(raw string) (pgType, goType, goImport string, err error) {
 	}
 
 	pgType = split[0]
+	goType, goImport, err = ParseGoType(split[1])
 
-	ind := strings.LastIndexByte(split[1], '.')
+	return
+}
+
+// ParseGoType parses go type with import path, e.g. github.com/google/uuid.UUID
+// type without import path is returned as is
+func ParseGoType(raw string) (goType, goImport string, err error) {
+	ind := strings.LastIndexByte(raw, '.')
 	if ind == -1 {
-		goType = split[1]
+		goType = raw
 		return
 	}
 
-	goImport, goType = split[1][:ind], split[1][ind:]
+	goImport, goType = raw[:ind], raw[ind:]
 
 	if goType == "." || goImport == "" {
 		err = fmt.Errorf("custom type mapping has invalid format (missing type or import)")
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"github.com/dizzyfool/genna/util"
+)
+
+// Domain stores information about pg domain type, column type is set to base type of domain
+type Domain struct {
+	GoName string
+
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	// Checks are check constraints of domain and its base domains, VALUE keyword in expression is a column value
+	Checks []Check
+}
+
+// NewDomain creates Domain from pg info
+func NewDomain(schema, pgName string, checks []Check) Domain {
+	goName := util.CamelCased(util.Sanitize(pgName))
+	if schema != util.PublicSchema {
+		goName = util.CamelCased(schema) + goName
+	}
+
+	return Domain{
+		GoName: goName,
+
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.JoinF(schema, pgName),
+
+		Checks: checks,
+	}
+}
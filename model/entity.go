@@ -4,6 +4,18 @@ import (
 	"github.com/dizzyfool/genna/util"
 )
 
+const (
+	// KindTable is a regular or partitioned table
+	KindTable EntityKind = "table"
+	// KindView is a view
+	KindView EntityKind = "view"
+	// KindMaterializedView is a materialized view
+	KindMaterializedView EntityKind = "materialized view"
+)
+
+// EntityKind is a kind of relation entity was created from
+type EntityKind string
+
 // Entity stores information about table
 type Entity struct {
 	GoName       string
@@ -12,11 +24,21 @@ type Entity struct {
 	PGSchema     string
 	PGFullName   string
 
+	Kind EntityKind
+
 	ViewName string
 
+	// Comment is a table comment set by COMMENT ON TABLE
+	Comment string
+
 	Columns   []Column
 	Relations []Relation
 
+	// Checks are check constraints of table
+	Checks []Check
+	// Indexes are indexes of table including ones created by primary key and unique constraints
+	Indexes []Index
+
 	Imports []string
 
 	// helper indexes
@@ -31,18 +53,15 @@ func NewEntity(schema, pgName string, columns []Column, relations []Relation) En
 		goName = util.CamelCased(schema) + goName
 	}
 
-	goNamePlural := util.CamelCased(util.Sanitize(pgName))
-	if schema != util.PublicSchema {
-		goNamePlural = util.CamelCased(schema) + goNamePlural
-	}
-
 	entity := Entity{
 		GoName:       goName,
-		GoNamePlural: goNamePlural,
+		GoNamePlural: pluralName(schema, pgName),
 		PGName:       pgName,
 		PGSchema:     schema,
 		PGFullName:   util.JoinF(schema, pgName),
 
+		Kind: KindTable,
+
 		Columns:   []Column{},
 		Relations: []Relation{},
 		colIndex:  util.NewIndex(),
@@ -92,6 +111,11 @@ func (e *Entity) AddRelation(relation Relation) {
 
 	e.Relations = append(e.Relations, relation)
 
+	// only has-one relation fields belong to entity
+	if relation.Type != HasOne {
+		return
+	}
+
 	// adding relation to column
 	for _, field := range relation.FKFields {
 		for i, column := range e.Columns {
@@ -102,6 +126,38 @@ func (e *Entity) AddRelation(relation Relation) {
 	}
 }
 
+// AddCheck adds check constraint to entity
+func (e *Entity) AddCheck(check Check) {
+	e.Checks = append(e.Checks, check)
+}
+
+// AddIndex adds index to entity, columns are marked unique by single column unique indexes
+func (e *Entity) AddIndex(index Index) {
+	e.Indexes = append(e.Indexes, index)
+
+	if !index.IsUniqueKey() || index.IsPrimary || len(index.Columns) != 1 {
+		return
+	}
+
+	for i, column := range e.Columns {
+		if column.PGName == index.Columns[0] {
+			e.Columns[i].IsUnique = true
+		}
+	}
+}
+
+// UniqueKeys gets unique indexes which could be used to find one row, primary key is not included
+func (e *Entity) UniqueKeys() []Index {
+	var result []Index
+	for _, index := range e.Indexes {
+		if index.IsUniqueKey() && !index.IsPrimary {
+			result = append(result, index)
+		}
+	}
+
+	return result
+}
+
 // HasMultiplePKs checks if entity has many primary keys
 func (e *Entity) HasMultiplePKs() bool {
 	counter := 0
@@ -117,3 +173,13 @@ func (e *Entity) HasMultiplePKs() bool {
 
 	return false
 }
+
+// IsView checks if entity is a view or a materialized view
+func (e *Entity) IsView() bool {
+	return e.Kind == KindView || e.Kind == KindMaterializedView
+}
+
+// ReadOnly checks if entity can not be written to
+func (e *Entity) ReadOnly() bool {
+	return e.IsView()
+}
@@ -152,6 +152,41 @@ func TestEntity_AddRelation(t *testing.T) {
 	})
 }
 
+func TestEntity_AddIndex(t *testing.T) {
+	columns := []Column{
+		NewColumn("id", TypePGInt4, "", false, false, false, false, 0, true, false, 0, []string{}, 9, CustomTypeMapping{}),
+		NewColumn("email", TypePGText, "", false, false, false, false, 0, false, false, 0, []string{}, 9, CustomTypeMapping{}),
+		NewColumn("login", TypePGText, "", false, false, false, false, 0, false, false, 0, []string{}, 9, CustomTypeMapping{}),
+		NewColumn("tenantId", TypePGInt4, "", false, false, false, false, 0, false, false, 0, []string{}, 9, CustomTypeMapping{}),
+	}
+	entity := NewEntity(util.PublicSchema, "users", columns, nil)
+
+	entity.AddIndex(Index{Name: "users_pkey", Columns: []string{"id"}, IsUnique: true, IsPrimary: true})
+	entity.AddIndex(Index{Name: "users_email_key", Columns: []string{"email"}, IsUnique: true})
+	entity.AddIndex(Index{Name: "users_login_idx", Columns: []string{"login"}, IsUnique: true, Predicate: "login is not null"})
+	entity.AddIndex(Index{Name: "users_tenantId_login_key", Columns: []string{"tenantId", "login"}, IsUnique: true})
+
+	t.Run("Should mark single column unique", func(t *testing.T) {
+		want := []bool{false, true, false, false}
+		for i, column := range entity.Columns {
+			if column.IsUnique != want[i] {
+				t.Errorf("Entity.Columns[%d].IsUnique = %v, want %v", i, column.IsUnique, want[i])
+			}
+		}
+	})
+
+	t.Run("Should get unique keys", func(t *testing.T) {
+		keys := entity.UniqueKeys()
+		if len(keys) != 2 {
+			t.Errorf("Entity.UniqueKeys() = %v, want %v", len(keys), 2)
+			return
+		}
+		if keys[0].Name != "users_email_key" || keys[1].Name != "users_tenantId_login_key" {
+			t.Errorf("Entity.UniqueKeys() = %v, %v", keys[0].Name, keys[1].Name)
+		}
+	})
+}
+
 func TestEntity_HasMultiplePKs(t *testing.T) {
 	entity := NewEntity(util.PublicSchema, "test", nil, nil)
 
@@ -174,3 +209,42 @@ func TestEntity_HasMultiplePKs(t *testing.T) {
 		})
 	})
 }
+
+func TestEntity_IsView(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     EntityKind
+		view     bool
+		readOnly bool
+	}{
+		{
+			name: "Should not be view for table",
+			kind: KindTable,
+		},
+		{
+			name:     "Should be view for view",
+			kind:     KindView,
+			view:     true,
+			readOnly: true,
+		},
+		{
+			name:     "Should be view for materialized view",
+			kind:     KindMaterializedView,
+			view:     true,
+			readOnly: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := NewEntity(util.PublicSchema, "test", nil, nil)
+			entity.Kind = tt.kind
+
+			if got := entity.IsView(); got != tt.view {
+				t.Errorf("Entity.IsView() = %v, want %v", got, tt.view)
+			}
+			if got := entity.ReadOnly(); got != tt.readOnly {
+				t.Errorf("Entity.ReadOnly() = %v, want %v", got, tt.readOnly)
+			}
+		})
+	}
+}
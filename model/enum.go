@@ -0,0 +1,78 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dizzyfool/genna/util"
+)
+
+// Enum stores information about pg enum type
+type Enum struct {
+	GoName string
+
+	PGName     string
+	PGSchema   string
+	PGFullName string
+
+	Values []string
+}
+
+// NewEnum creates Enum from pg info
+func NewEnum(schema, pgName string, values []string) Enum {
+	goName := util.CamelCased(util.Sanitize(pgName))
+	if schema != util.PublicSchema {
+		goName = util.CamelCased(schema) + goName
+	}
+
+	return Enum{
+		GoName: goName,
+
+		PGName:     pgName,
+		PGSchema:   schema,
+		PGFullName: util.JoinF(schema, pgName),
+
+		Values: values,
+	}
+}
+
+// ValueGoName gets name of go constant for enum value
+func (e Enum) ValueGoName(value string) string {
+	names := e.ValueGoNames()
+	for i, v := range e.Values {
+		if v == value {
+			return names[i]
+		}
+	}
+
+	return e.GoName + valueGoName(value)
+}
+
+// ValueGoNames gets names of go constants for all enum values,
+// values with same name after sanitizing get numeric suffix starting from 2
+func (e Enum) ValueGoNames() []string {
+	names := make([]string, len(e.Values))
+	used := map[string]bool{}
+	for i, value := range e.Values {
+		base := e.GoName + valueGoName(value)
+
+		name := base
+		for n := 2; used[name]; n++ {
+			name = base + strconv.Itoa(n)
+		}
+
+		used[name] = true
+		names[i] = name
+	}
+
+	return names
+}
+
+func valueGoName(value string) string {
+	name := util.CamelCased(util.Sanitize(strings.NewReplacer(" ", "_", ".", "_").Replace(value)))
+	if name == "" {
+		name = "Empty"
+	}
+
+	return name
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dizzyfool/genna/util"
+)
+
+func TestNewEnum(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		pgName string
+		want   string
+	}{
+		{
+			name:   "Should generate name",
+			schema: util.PublicSchema,
+			pgName: "order_status",
+			want:   "OrderStatus",
+		},
+		{
+			name:   "Should generate name with schema",
+			schema: "shop",
+			pgName: "order_status",
+			want:   "ShopOrderStatus",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewEnum(tt.schema, tt.pgName, nil).GoName; got != tt.want {
+				t.Errorf("Enum.GoName = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnum_ValueGoName(t *testing.T) {
+	enum := NewEnum(util.PublicSchema, "order_status", nil)
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "Should generate from simple value",
+			value: "new",
+			want:  "OrderStatusNew",
+		},
+		{
+			name:  "Should generate from value with spaces",
+			value: "in progress",
+			want:  "OrderStatusInProgress",
+		},
+		{
+			name:  "Should generate from dashed value",
+			value: "on-hold",
+			want:  "OrderStatusOnHold",
+		},
+		{
+			name:  "Should generate from empty value",
+			value: "",
+			want:  "OrderStatusEmpty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := enum.ValueGoName(tt.value); got != tt.want {
+				t.Errorf("Enum.ValueGoName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnum_ValueGoNames(t *testing.T) {
+	enum := NewEnum(util.PublicSchema, "order_status", []string{"a-b", "a_b", "A B", "ab2", "new"})
+
+	want := []string{"OrderStatusAB", "OrderStatusAB2", "OrderStatusAB3", "OrderStatusAb2", "OrderStatusNew"}
+	if got := enum.ValueGoNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Enum.ValueGoNames() = %v, want %v", got, want)
+	}
+
+	if got := enum.ValueGoName("A B"); got != "OrderStatusAB3" {
+		t.Errorf("Enum.ValueGoName() = %v, want %v", got, "OrderStatusAB3")
+	}
+}
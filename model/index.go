@@ -0,0 +1,33 @@
+package model
+
+// IndexMethodBtree is default index access method
+const IndexMethodBtree = "btree"
+
+// Index stores information about table index, primary keys and unique constraints are backed by indexes too
+type Index struct {
+	Name string
+
+	// Columns are names of indexed columns, expressions are not included
+	Columns        []string
+	HasExpressions bool
+
+	IsUnique  bool
+	IsPrimary bool
+	// IsConstraint is set if index is created by unique constraint
+	IsConstraint bool
+
+	// Method is index access method, e.g. btree or gin
+	Method string
+	// Predicate is condition of partial index
+	Predicate string
+}
+
+// IsPartial checks if index covers only rows matching predicate
+func (i Index) IsPartial() bool {
+	return i.Predicate != ""
+}
+
+// IsUniqueKey checks if index guarantees uniqueness of its columns values for every row
+func (i Index) IsUniqueKey() bool {
+	return i.IsUnique && !i.IsPartial() && !i.HasExpressions && len(i.Columns) > 0
+}
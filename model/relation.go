@@ -6,11 +6,37 @@ import (
 	"github.com/dizzyfool/genna/util"
 )
 
+const (
+	// HasOne is a relation from entity with foreign key to referenced entity
+	HasOne RelationType = "has-one"
+	// HasMany is a relation from referenced entity to entities with foreign key
+	HasMany RelationType = "has-many"
+	// ManyToMany is a relation between two entities referenced by junction table
+	ManyToMany RelationType = "many2many"
+)
+
+// RelationType is a type of relation
+type RelationType string
+
 // Relation stores relation
 type Relation struct {
+	Type RelationType
+
+	// FKFields are foreign key columns of entity for has-one relation,
+	// of target entity for has-many relation, of junction table for many2many relation
 	FKFields []string
 	GoName   string
 
+	// PKFields are columns referenced by FKFields
+	PKFields []string
+
+	// JoinFKFields are junction table columns referencing target entity
+	JoinFKFields []string
+	// JoinPKFields are target entity columns referenced by JoinFKFields
+	JoinPKFields []string
+	// Through is a junction table entity for many2many relation
+	Through *Entity
+
 	TargetPGName     string
 	TargetPGSchema   string
 	TargetPGFullName string
@@ -33,6 +59,7 @@ func NewRelation(sourceColumns []string, targetSchema, targetTable string) Relat
 	}
 
 	return Relation{
+		Type:     HasOne,
 		FKFields: sourceColumns,
 		GoName:   strings.Join(names, ""),
 
@@ -44,6 +71,88 @@ func NewRelation(sourceColumns []string, targetSchema, targetTable string) Relat
 	}
 }
 
+// NewHasManyRelation creates relation from referenced entity to entities with foreign key
+// targetColumns are foreign key columns of target table
+func NewHasManyRelation(targetColumns []string, targetSchema, targetTable string) Relation {
+	relation := NewRelation(targetColumns, targetSchema, targetTable)
+
+	relation.Type = HasMany
+	relation.GoName = pluralName(targetSchema, targetTable)
+
+	return relation
+}
+
+// NewManyToManyRelation creates relation to target entity through junction table
+// columns and targetColumns are junction columns referencing entity and target entity
+func NewManyToManyRelation(columns, targetColumns []string, through *Entity, targetSchema, targetTable string) Relation {
+	relation := NewRelation(columns, targetSchema, targetTable)
+
+	relation.Type = ManyToMany
+	relation.GoName = pluralName(targetSchema, targetTable)
+	relation.JoinFKFields = targetColumns
+	relation.Through = through
+
+	return relation
+}
+
+// SetPKFields sets columns referenced by foreign key
+// for composite keys columns with the same name as referenced ones (e.g. tenantId) are excluded from the name
+func (r *Relation) SetPKFields(pkFields []string) {
+	r.PKFields = pkFields
+
+	if r.Type != HasOne || len(r.FKFields) < 2 || len(pkFields) != len(r.FKFields) {
+		return
+	}
+
+	var names []string
+	for i, name := range r.FKFields {
+		if name != pkFields[i] {
+			names = append(names, util.ReplaceSuffix(util.ColumnName(name), util.ID, ""))
+		}
+	}
+
+	if len(names) > 0 {
+		r.GoName = strings.Join(names, "")
+	}
+}
+
+// ReferencesPK checks if foreign key references primary key of target entity
+// relation without target entity or referenced columns is considered referencing primary key
+func (r Relation) ReferencesPK() bool {
+	if r.TargetEntity == nil || len(r.PKFields) == 0 {
+		return true
+	}
+
+	pks := util.NewSet()
+	for _, column := range r.TargetEntity.Columns {
+		if column.IsPK {
+			pks.Add(column.PGName)
+		}
+	}
+
+	if pks.Len() != len(r.PKFields) {
+		return false
+	}
+
+	for _, field := range r.PKFields {
+		if !pks.Exists(field) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddEntity sets target entity of relation
 func (r *Relation) AddEntity(entity *Entity) {
 	r.TargetEntity = entity
 }
+
+func pluralName(schema, table string) string {
+	name := util.CamelCased(util.Sanitize(table))
+	if schema != util.PublicSchema {
+		name = util.CamelCased(schema) + name
+	}
+
+	return name
+}
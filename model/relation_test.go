@@ -146,3 +146,138 @@ func TestRelation_GoType(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHasManyRelation(t *testing.T) {
+	tests := []struct {
+		name          string
+		targetColumns []string
+		targetSchema  string
+		targetTable   string
+		want          string
+	}{
+		{
+			name:          "Should generate plural name",
+			targetColumns: []string{"userId"},
+			targetSchema:  util.PublicSchema,
+			targetTable:   "orders",
+			want:          "Orders",
+		},
+		{
+			name:          "Should generate plural name with schema",
+			targetColumns: []string{"itemId"},
+			targetSchema:  "shop",
+			targetTable:   "item_tags",
+			want:          "ShopItemTags",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewHasManyRelation(tt.targetColumns, tt.targetSchema, tt.targetTable)
+			if got := r.GoName; got != tt.want {
+				t.Errorf("Relation.GoName = %v, want %v", got, tt.want)
+			}
+			if r.Type != HasMany {
+				t.Errorf("Relation.Type = %v, want %v", r.Type, HasMany)
+			}
+		})
+	}
+}
+
+func TestNewManyToManyRelation(t *testing.T) {
+	through := NewEntity("shop", "item_tags", nil, nil)
+
+	r := NewManyToManyRelation([]string{"itemId"}, []string{"tagId"}, &through, "shop", "tags")
+	if r.Type != ManyToMany {
+		t.Errorf("Relation.Type = %v, want %v", r.Type, ManyToMany)
+	}
+	if r.GoName != "ShopTags" {
+		t.Errorf("Relation.GoName = %v, want %v", r.GoName, "ShopTags")
+	}
+	if r.GoType != "ShopTag" {
+		t.Errorf("Relation.GoType = %v, want %v", r.GoType, "ShopTag")
+	}
+	if r.Through != &through {
+		t.Errorf("Relation.Through = %v, want %v", r.Through, &through)
+	}
+}
+
+func TestRelation_SetPKFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		fkFields []string
+		pkFields []string
+		want     string
+	}{
+		{
+			name:     "Should keep simple name",
+			fkFields: []string{"customerId"},
+			pkFields: []string{"id"},
+			want:     "Customer",
+		},
+		{
+			name:     "Should exclude shared columns from composite name",
+			fkFields: []string{"tenantId", "customerId"},
+			pkFields: []string{"tenantId", "id"},
+			want:     "Customer",
+		},
+		{
+			name:     "Should keep composite name if all columns are shared",
+			fkFields: []string{"tenantId", "customerId"},
+			pkFields: []string{"tenantId", "customerId"},
+			want:     "TenantCustomer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRelation(tt.fkFields, util.PublicSchema, "customers")
+			r.SetPKFields(tt.pkFields)
+			if got := r.GoName; got != tt.want {
+				t.Errorf("Relation.GoName = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelation_ReferencesPK(t *testing.T) {
+	target := NewEntity(util.PublicSchema, "customers", []Column{
+		{PGName: "tenantId", IsPK: true},
+		{PGName: "id", IsPK: true},
+		{PGName: "code"},
+	}, nil)
+
+	tests := []struct {
+		name     string
+		pkFields []string
+		want     bool
+	}{
+		{
+			name:     "Should reference primary key",
+			pkFields: []string{"id", "tenantId"},
+			want:     true,
+		},
+		{
+			name:     "Should not reference part of primary key",
+			pkFields: []string{"id"},
+			want:     false,
+		},
+		{
+			name:     "Should not reference unique columns",
+			pkFields: []string{"tenantId", "code"},
+			want:     false,
+		},
+		{
+			name: "Should reference primary key without referenced columns",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRelation([]string{"tenantId", "customerId"}, util.PublicSchema, "customers")
+			r.SetPKFields(tt.pkFields)
+			r.AddEntity(&target)
+			if got := r.ReferencesPK(); got != tt.want {
+				t.Errorf("Relation.ReferencesPK() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
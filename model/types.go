@@ -53,6 +53,52 @@ const (
 	TypePGCidr = "cidr"
 	// TypePGPoint is a postgres type
 	TypePGPoint = "point"
+	// TypePGInt4range is a postgres type
+	TypePGInt4range = "int4range"
+	// TypePGInt8range is a postgres type
+	TypePGInt8range = "int8range"
+	// TypePGNumrange is a postgres type
+	TypePGNumrange = "numrange"
+	// TypePGDaterange is a postgres type
+	TypePGDaterange = "daterange"
+	// TypePGTsrange is a postgres type
+	TypePGTsrange = "tsrange"
+	// TypePGTstzrange is a postgres type
+	TypePGTstzrange = "tstzrange"
+	// TypePGTsvector is a postgres type
+	TypePGTsvector = "tsvector"
+	// TypePGTsquery is a postgres type
+	TypePGTsquery = "tsquery"
+	// TypePGMoney is a postgres type
+	TypePGMoney = "money"
+	// TypePGBit is a postgres type
+	TypePGBit = "bit"
+	// TypePGVarbit is a postgres type
+	TypePGVarbit = "varbit"
+	// TypePGXML is a postgres type
+	TypePGXML = "xml"
+	// TypePGCitext is a postgres type
+	TypePGCitext = "citext"
+	// TypePGLtree is a postgres type
+	TypePGLtree = "ltree"
+	// TypePGMacaddr is a postgres type
+	TypePGMacaddr = "macaddr"
+	// TypePGMacaddr8 is a postgres type
+	TypePGMacaddr8 = "macaddr8"
+	// TypePGOid is a postgres type
+	TypePGOid = "oid"
+	// TypePGBox is a postgres type
+	TypePGBox = "box"
+	// TypePGPolygon is a postgres type
+	TypePGPolygon = "polygon"
+	// TypePGLine is a postgres type
+	TypePGLine = "line"
+	// TypePGLseg is a postgres type
+	TypePGLseg = "lseg"
+	// TypePGPath is a postgres type
+	TypePGPath = "path"
+	// TypePGCircle is a postgres type
+	TypePGCircle = "circle"
 
 	// TypeInt is a go type
 	TypeInt = "int"
@@ -83,8 +129,14 @@ const (
 	// TypeIPNet is a go type
 	TypeIPNet = "net.IPNet"
 
+	// TypeDecimal is a go type
+	TypeDecimal = "decimal.Decimal"
+
 	// TypeInterface is a go type
 	TypeInterface = "interface{}"
+
+	// DecimalImport is import path of TypeDecimal
+	DecimalImport = "github.com/shopspring/decimal"
 )
 
 // GoType generates simple go type from pg type
@@ -116,22 +168,52 @@ func GoType(pgType string) (string, error) {
 		return TypeIP, nil
 	case TypePGCidr:
 		return TypeIPNet, nil
+	case TypePGOid:
+		return TypeInt64, nil
+	}
+
+	// types without go counterpart are kept in text representation
+	if isTextual(pgType) {
+		return TypeString, nil
 	}
 
 	return "", fmt.Errorf("unsupported type: %s", pgType)
 }
 
-// GoSlice generates go slice type from pg array
-func GoSlice(pgType string, dimensions int) (string, error) {
+// isTextual checks if pg type is read as string
+func isTextual(pgType string) bool {
 	switch pgType {
-	case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz,
-		TypePGInterval, TypePGHstore, TypePGInet, TypePGCidr:
-		return "", fmt.Errorf("unsupported array type: %s", pgType)
+	case TypePGInt4range, TypePGInt8range, TypePGNumrange, TypePGDaterange, TypePGTsrange, TypePGTstzrange,
+		TypePGTsvector, TypePGTsquery, TypePGMoney, TypePGBit, TypePGVarbit, TypePGXML, TypePGCitext, TypePGLtree,
+		TypePGMacaddr, TypePGMacaddr8, TypePGBox, TypePGPolygon, TypePGLine, TypePGLseg, TypePGPath, TypePGCircle:
+		return true
 	}
 
-	typ, err := GoType(pgType)
-	if err != nil {
-		return "", err
+	return false
+}
+
+// TypeTag gets pg type for struct tag of columns which go type does not define pg type, e.g. uuid or tsvector stored in string
+func TypeTag(pgType string) (string, bool) {
+	if pgType == TypePGUuid || pgType == TypePGOid || isTextual(pgType) {
+		return pgType, true
+	}
+
+	return "", false
+}
+
+// GoSlice generates go slice type from pg array
+func GoSlice(pgType string, dimensions int, customTypes CustomTypeMapping) (string, error) {
+	typ, ok := customTypes.GoType(pgType)
+	if !ok {
+		switch pgType {
+		case TypePGInterval, TypePGHstore:
+			return "", fmt.Errorf("unsupported array type: %s", pgType)
+		}
+
+		var err error
+		if typ, err = GoType(pgType); err != nil {
+			return "", err
+		}
 	}
 
 	// slice can not have 0 dimensions
@@ -151,7 +233,7 @@ func GoNullable(pgType string, useSQLNull bool, customTypes CustomTypeMapping) (
 	// avoiding pointers with sql.Null... types
 	if useSQLNull {
 		switch pgType {
-		case TypePGInt2, TypePGInt4, TypePGInt8:
+		case TypePGInt2, TypePGInt4, TypePGInt8, TypePGOid:
 			return "sql.NullInt64", nil
 		case TypePGNumeric, TypePGFloat4, TypePGFloat8:
 			return "sql.NullFloat64", nil
@@ -162,6 +244,10 @@ func GoNullable(pgType string, useSQLNull bool, customTypes CustomTypeMapping) (
 		case TypePGTimestamp, TypePGTimestamptz, TypePGDate, TypePGTime, TypePGTimetz:
 			return "pg.NullTime", nil
 		}
+
+		if isTextual(pgType) {
+			return "sql.NullString", nil
+		}
 	}
 
 	if typ, ok := customTypes.GoType(pgType); ok && typ != "" {
@@ -186,7 +272,7 @@ func GoNullable(pgType string, useSQLNull bool, customTypes CustomTypeMapping) (
 func GoImport(pgType string, nullable, useSQLNull bool, ver int) string {
 	if nullable && useSQLNull {
 		switch pgType {
-		case TypePGInt2, TypePGInt4, TypePGInt8,
+		case TypePGInt2, TypePGInt4, TypePGInt8, TypePGOid,
 			TypePGNumeric, TypePGFloat4, TypePGFloat8,
 			TypePGBool,
 			TypePGText, TypePGVarchar, TypePGUuid, TypePGBpchar, TypePGPoint:
@@ -198,6 +284,10 @@ func GoImport(pgType string, nullable, useSQLNull bool, ver int) string {
 				return "github.com/go-pg/pg"
 			}
 		}
+
+		if isTextual(pgType) {
+			return "database/sql"
+		}
 	}
 
 	switch pgType {
@@ -82,6 +82,20 @@ func Test_goType(t *testing.T) {
 			pgTypes: []string{TypePGCidr},
 			want:    TypeIPNet,
 		},
+		{
+			name: "Should get string for types without go counterpart",
+			pgTypes: []string{
+				TypePGInt4range, TypePGInt8range, TypePGNumrange, TypePGDaterange, TypePGTsrange, TypePGTstzrange,
+				TypePGTsvector, TypePGTsquery, TypePGMoney, TypePGBit, TypePGVarbit, TypePGXML, TypePGCitext, TypePGLtree,
+				TypePGMacaddr, TypePGMacaddr8, TypePGBox, TypePGPolygon, TypePGLine, TypePGLseg, TypePGPath, TypePGCircle,
+			},
+			want: TypeString,
+		},
+		{
+			name:    "Should get int64 for oid",
+			pgTypes: []string{TypePGOid},
+			want:    TypeInt64,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -185,9 +199,24 @@ func Test_goSlice(t *testing.T) {
 			args: args{TypePGPoint, 1},
 			want: "[]string",
 		},
+		{
+			name: "Should generate time array",
+			args: args{TypePGTimestamptz, 1},
+			want: "[]time.Time",
+		},
+		{
+			name: "Should generate inet array",
+			args: args{TypePGInet, 1},
+			want: "[]net.IP",
+		},
+		{
+			name: "Should generate tsvector array",
+			args: args{TypePGTsvector, 1},
+			want: "[]string",
+		},
 		{
 			name:    "Should not generate not supported type array",
-			args:    args{TypePGTimetz, 1},
+			args:    args{TypePGInterval, 1},
 			wantErr: true,
 		},
 		{
@@ -198,7 +227,7 @@ func Test_goSlice(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := GoSlice(tt.args.pgType, tt.args.dimensions)
+			got, err := GoSlice(tt.args.pgType, tt.args.dimensions, CustomTypeMapping{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GoSlice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -343,6 +372,17 @@ func Test_goNullable(t *testing.T) {
 			avoidPointers: true,
 			want:          "sql.NullFloat64",
 		},
+		{
+			name:   "Should generate tsvector type",
+			pgType: TypePGTsvector,
+			want:   "*string",
+		},
+		{
+			name:          "Should generate money type avoiding pointers to sql.NullString",
+			pgType:        TypePGMoney,
+			avoidPointers: true,
+			want:          "sql.NullString",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
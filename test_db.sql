@@ -1,5 +1,6 @@
 drop schema if exists "public" cascade;
 drop schema if exists "geo" cascade;
+drop schema if exists "shop" cascade;
 
 create schema "public";
 
@@ -43,4 +44,91 @@ create table geo."countries"
 alter table "users"
     add constraint "fk_user_country"
         foreign key ("countryId")
-            references geo."countries" ("countryId") on update restrict on delete restrict;
\ No newline at end of file
+            references geo."countries" ("countryId") on update restrict on delete restrict;
+
+create view "active_users" as
+    select "userId", "email", "name", "countryId"
+    from "users"
+    where "activated";
+
+create materialized view geo."country_stats" as
+    select c."countryId", c."code", count(u."userId") as "usersCount"
+    from geo."countries" c
+    left join "users" u using ("countryId")
+    group by c."countryId", c."code";
+
+create schema "shop";
+
+create table shop."items"
+(
+    "itemId" serial not null,
+    "name"   text   not null check (length("name") between 1 and 128),
+    "price"  numeric(10, 2),
+
+    primary key ("itemId")
+);
+
+create table shop."tags"
+(
+    "tagId" serial not null,
+    "name"  text   not null unique check ("name" ~ '^[a-z0-9-]+$'),
+
+    primary key ("tagId")
+);
+
+create table shop."item_tags"
+(
+    "itemId" integer not null references shop."items" ("itemId"),
+    "tagId"  integer not null references shop."tags" ("tagId"),
+
+    primary key ("itemId", "tagId")
+);
+
+create type shop."order_status" as enum ('new', 'in progress', 'done');
+
+create domain shop."quantity" as integer not null check (value > 0);
+
+create table shop."orders"
+(
+    "orderId"        serial            not null,
+    "itemId"         integer           not null references shop."items" ("itemId"),
+    "sellerId"       integer           not null references "users" ("userId"),
+    "buyerId"        integer           not null references "users" ("userId"),
+    "status"         shop.order_status not null default 'new',
+    "previousStatus" shop.order_status,
+    "quantity"       shop.quantity     default 1,
+
+    primary key ("orderId"),
+    check ("sellerId" <> "buyerId")
+);
+
+comment on table shop."orders" is 'Orders placed by buyers';
+comment on column shop."orders"."status" is 'Current status of the order';
+
+create type shop."address" as
+(
+    "street" text,
+    "city"   text,
+    "zip"    varchar(10)
+);
+
+create table shop."customers"
+(
+    "tenant_id" integer      not null check ("tenant_id" > 0),
+    "id"        serial       not null,
+    "name"      text         not null,
+    "address"   shop.address,
+
+    primary key ("tenant_id", "id"),
+    unique ("tenant_id", "name")
+);
+
+create table shop."invoices"
+(
+    "tenant_id"   integer not null,
+    "id"          serial  not null,
+    "customer_id" integer not null,
+
+    primary key ("tenant_id", "id"),
+    foreign key ("tenant_id", "customer_id") references shop."customers" ("tenant_id", "id")
+);
@@ -0,0 +1,207 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is a number of unchanged lines around changes in unified diff
+const diffContext = 3
+
+// edit is a line of edit script
+type edit struct {
+	op   byte
+	line string
+}
+
+// Diff gets unified diff between two texts, empty string if texts are equal
+func Diff(fromName, toName string, from, to []byte) string {
+	if bytes.Equal(from, to) {
+		return ""
+	}
+
+	edits := editScript(lines(from), lines(to))
+
+	// line numbers of both texts before every edit
+	fromLines := make([]int, len(edits)+1)
+	toLines := make([]int, len(edits)+1)
+	for i, e := range edits {
+		fromLines[i+1], toLines[i+1] = fromLines[i], toLines[i]
+		if e.op != '+' {
+			fromLines[i+1]++
+		}
+		if e.op != '-' {
+			toLines[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	for i := 0; i < len(edits); {
+		for i < len(edits) && edits[i].op == ' ' {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+
+		// hunk includes changes separated by less than two contexts
+		start, end := i-diffContext, i+1
+		if start < 0 {
+			start = 0
+		}
+		for j := i; j < len(edits) && j-end < 2*diffContext; j++ {
+			if edits[j].op != ' ' {
+				end = j + 1
+			}
+		}
+		end += diffContext
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(fromLines[start], fromLines[end]-fromLines[start]),
+			hunkRange(toLines[start], toLines[end]-toLines[start]),
+		)
+		for _, e := range edits[start:end] {
+			b.WriteByte(e.op)
+			b.WriteString(e.line)
+			if !strings.HasSuffix(e.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		i = end
+	}
+
+	return b.String()
+}
+
+// hunkRange formats start line and count of lines for hunk header
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// lines splits text to lines keeping line breaks
+func lines(text []byte) []string {
+	result := strings.SplitAfter(string(text), "\n")
+	if result[len(result)-1] == "" {
+		result = result[:len(result)-1]
+	}
+
+	return result
+}
+
+// editScript gets shortest edit script by linear space variation of Myers' algorithm
+func editScript(a, b []string) []edit {
+	return appendEdits(make([]edit, 0, len(a)+len(b)), a, b)
+}
+
+// appendEdits appends edit script of a to b, common prefix and suffix are skipped,
+// the rest is split by middle snake of shortest edit script and both parts are compared recursively
+func appendEdits(edits []edit, a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		edits = append(edits, edit{' ', a[prefix]})
+		prefix++
+	}
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			edits = append(edits, edit{'+', line})
+		}
+	case len(b) == 0:
+		for _, line := range a {
+			edits = append(edits, edit{'-', line})
+		}
+	default:
+		x, y, u, v := middleSnake(a, b)
+		edits = appendEdits(edits, a[:x], b[:y])
+		for _, line := range a[x:u] {
+			edits = append(edits, edit{' ', line})
+		}
+		edits = appendEdits(edits, a[u:], b[v:])
+	}
+
+	for _, line := range common {
+		edits = append(edits, edit{' ', line})
+	}
+
+	return edits
+}
+
+// middleSnake gets start (x, y) and end (u, v) of middle snake of shortest edit script of a to b
+// by searching forward from the start and backward from the end until paths overlap
+func middleSnake(a, b []string) (x, y, u, v int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+	max := (n + m + 1) / 2
+	offset := max + 1
+
+	// furthest x on diagonals k = x - y, backward paths count x and y from the end
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && a[u] == b[v] {
+				u++
+				v++
+			}
+			forward[offset+k] = u
+
+			// backward diagonal of the same line is delta - k
+			if c := delta - k; odd && c >= -(d-1) && c <= d-1 && u+backward[offset+c] >= n {
+				return x, y, u, v
+			}
+		}
+
+		for c := -d; c <= d; c += 2 {
+			if c == -d || (c != d && backward[offset+c-1] < backward[offset+c+1]) {
+				x = backward[offset+c+1]
+			} else {
+				x = backward[offset+c-1] + 1
+			}
+
+			y = x - c
+			u, v = x, y
+			for u < n && v < m && a[n-1-u] == b[m-1-v] {
+				u++
+				v++
+			}
+			backward[offset+c] = u
+
+			if k := delta - c; !odd && k >= -d && k <= d && u+forward[offset+k] >= n {
+				return n - u, m - v, n - x, m - y
+			}
+		}
+	}
+
+	// unreachable, paths overlap when d reaches half of edit script length
+	return n, m, n, m
+}
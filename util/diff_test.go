@@ -0,0 +1,122 @@
+package util
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			name: "Should get empty diff for equal texts",
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			want: "",
+		},
+		{
+			name: "Should get diff for new file",
+			from: "",
+			to:   "a\nb\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "Should get diff with context",
+			from: "1\n2\n3\n4\n5\n6\n",
+			to:   "1\n2\n3\nfour\n5\n6\n",
+			want: "--- old\n+++ new\n@@ -1,6 +1,6 @@\n 1\n 2\n 3\n-4\n+four\n 5\n 6\n",
+		},
+		{
+			name: "Should split distant changes to hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			to:   "one\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,3 @@\n 7\n 8\n 9\n-10\n",
+		},
+		{
+			name: "Should mark missing line break",
+			from: "a\n",
+			to:   "a",
+			want: "--- old\n+++ new\n@@ -1,1 +1,1 @@\n-a\n+a\n\\ No newline at end of file\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff("old", "new", []byte(tt.from), []byte(tt.to)); got != tt.want {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_editScript(t *testing.T) {
+	many := make([]string, 20000)
+	changed := make([]string, len(many))
+	for i := range many {
+		many[i] = strconv.Itoa(i) + "\n"
+		changed[i] = many[i]
+		if i%10 == 0 {
+			changed[i] = "changed\n"
+		}
+	}
+
+	tests := []struct {
+		name        string
+		from        []string
+		to          []string
+		wantChanges int
+	}{
+		{
+			name:        "Should get shortest edit script",
+			from:        []string{"a", "b", "c", "a", "b", "b", "a"},
+			to:          []string{"c", "b", "a", "b", "a", "c"},
+			wantChanges: 5,
+		},
+		{
+			name:        "Should get edit script without common lines",
+			from:        []string{"a", "b"},
+			to:          []string{"c", "d", "e"},
+			wantChanges: 5,
+		},
+		{
+			name:        "Should get edit script of long new file",
+			from:        nil,
+			to:          many,
+			wantChanges: len(many),
+		},
+		{
+			name:        "Should get edit script of long changed file",
+			from:        many,
+			to:          changed,
+			wantChanges: len(many) / 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var from, to []string
+			changes := 0
+			for _, e := range editScript(tt.from, tt.to) {
+				if e.op != '+' {
+					from = append(from, e.line)
+				}
+				if e.op != '-' {
+					to = append(to, e.line)
+				}
+				if e.op != ' ' {
+					changes++
+				}
+			}
+
+			if !reflect.DeepEqual(from, tt.from) || !reflect.DeepEqual(to, tt.to) {
+				t.Errorf("editScript() does not transform %v to %v", tt.from, tt.to)
+			}
+			if changes != tt.wantChanges {
+				t.Errorf("editScript() changes = %v, want %v", changes, tt.wantChanges)
+			}
+		})
+	}
+}
@@ -10,23 +10,39 @@ import (
 // FmtAndSave formats go code and saves file
 // if formatting failed it still saves file but return error also
 func FmtAndSave(unformatted []byte, filename string) (bool, error) {
-	// formatting by go-fmt
-	content, fmtErr := format.Source(unformatted)
-	if fmtErr != nil {
-		// saving file even if there is fmt errors
-		content = unformatted
+	content, fmtErr := Fmt(unformatted)
+
+	if err := Save(content, filename); err != nil {
+		return false, err
+	}
+
+	return true, fmtErr
+}
+
+// Fmt formats go code
+// if formatting failed it returns unformatted code with error
+func Fmt(unformatted []byte) ([]byte, error) {
+	content, err := format.Source(unformatted)
+	if err != nil {
+		return unformatted, err
 	}
 
+	return content, nil
+}
+
+// Save writes content to file creating directories if needed
+func Save(content []byte, filename string) error {
 	file, err := File(filename)
 	if err != nil {
-		return false, fmt.Errorf("open model file error: %w", err)
+		return fmt.Errorf("open model file error: %w", err)
 	}
+	defer file.Close()
 
 	if _, err := file.Write(content); err != nil {
-		return false, fmt.Errorf("writing content to file error: %w", err)
+		return fmt.Errorf("writing content to file error: %w", err)
 	}
 
-	return true, fmtErr
+	return nil
 }
 
 // File creates file
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionRgxp = regexp.MustCompile(`^v\d+$`)
+
+// RemoveUnusedImports removes imports not used in go code
+// package name is guessed by import path, imports with unknown name are kept
+func RemoveUnusedImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]struct{}{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	changed := false
+	for i := 0; i < len(file.Decls); i++ {
+		decl, ok := file.Decls[i].(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT {
+			continue
+		}
+
+		specs := decl.Specs[:0]
+		for _, spec := range decl.Specs {
+			name := importName(spec.(*ast.ImportSpec))
+			if _, ok := used[name]; ok || name == "" {
+				specs = append(specs, spec)
+				continue
+			}
+			changed = true
+		}
+		decl.Specs = specs
+
+		if len(decl.Specs) == 0 {
+			file.Decls = append(file.Decls[:i], file.Decls[i+1:]...)
+			i--
+		}
+	}
+
+	if !changed {
+		return src, nil
+	}
+
+	var imports []*ast.ImportSpec
+	for _, imp := range file.Imports {
+		if _, ok := used[importName(imp)]; ok || importName(imp) == "" {
+			imports = append(imports, imp)
+		}
+	}
+	file.Imports = imports
+
+	var buffer bytes.Buffer
+	if err := format.Node(&buffer, fset, file); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// importName gets name of imported package, empty if it can not be guessed or import should be kept
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return ""
+		}
+		return spec.Name.Name
+	}
+
+	importPath, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return ""
+	}
+
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+	if versionRgxp.MatchString(name) && len(parts) > 1 {
+		name = parts[len(parts)-2]
+	}
+
+	if !token.IsIdentifier(name) {
+		return ""
+	}
+
+	return name
+}
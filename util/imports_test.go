@@ -0,0 +1,92 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestRemoveUnusedImports(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "Should remove unused imports",
+			src: `package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/go-pg/pg/v10"
+)
+
+var t time.Time
+var q = pg.Ident("")
+`,
+			want: `package model
+
+import (
+	"time"
+
+	"github.com/go-pg/pg/v10"
+)
+
+var t time.Time
+var q = pg.Ident("")
+`,
+		},
+		{
+			name: "Should remove import declaration",
+			src: `package model
+
+import (
+	"fmt"
+)
+
+var a int
+`,
+			want: `package model
+
+var a int
+`,
+		},
+		{
+			name: "Should keep aliased and unknown imports",
+			src: `package model
+
+import (
+	_ "embed"
+	str "strings"
+	"github.com/some/go-lib"
+	"github.com/some/unused"
+)
+
+var a = str.ToLower("")
+`,
+			want: `package model
+
+import (
+	_ "embed"
+	"github.com/some/go-lib"
+	str "strings"
+)
+
+var a = str.ToLower("")
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemoveUnusedImports([]byte(tt.src))
+			if err != nil {
+				t.Errorf("RemoveUnusedImports() error = %v", err)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("RemoveUnusedImports() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
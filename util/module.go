@@ -0,0 +1,60 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImportPath gets go import path of directory by go.mod file found in it or its parents
+func ImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for root := abs; ; root = filepath.Dir(root) {
+		module, err := moduleName(filepath.Join(root, "go.mod"))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+
+		if module != "" {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+
+			if rel == "." {
+				return module, nil
+			}
+
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("go.mod not found for %s", dir)
+		}
+	}
+}
+
+// moduleName reads module name from go.mod file
+func moduleName(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`), nil
+		}
+	}
+
+	return "", scanner.Err()
+}
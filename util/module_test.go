@@ -0,0 +1,51 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestImportPath(t *testing.T) {
+	root, err := ioutil.TempDir("", "genna_module")
+	if err != nil {
+		t.Errorf("creating temp dir error = %v", err)
+		return
+	}
+	defer os.RemoveAll(root)
+
+	if err := ioutil.WriteFile(path.Join(root, "go.mod"), []byte("module github.com/user/project\n\ngo 1.18\n"), 0644); err != nil {
+		t.Errorf("writing go.mod error = %v", err)
+		return
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{
+			name: "Should get module path for module root",
+			dir:  root,
+			want: "github.com/user/project",
+		},
+		{
+			name: "Should get import path for nested directory",
+			dir:  path.Join(root, "internal", "model"),
+			want: "github.com/user/project/internal/model",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ImportPath(tt.dir)
+			if err != nil {
+				t.Errorf("ImportPath() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ImportPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -32,6 +32,11 @@ func Singular(s string) string {
 	return inflection.Singular(s)
 }
 
+// Plural makes plural of singular english word
+func Plural(s string) string {
+	return inflection.Plural(s)
+}
+
 // IsUpper check rune for upper case
 func IsUpper(c byte) bool {
 	return c >= 'A' && c <= 'Z'
@@ -48,6 +48,40 @@ func TestSingular(t *testing.T) {
 	}
 }
 
+func TestPlural(t *testing.T) {
+	type args struct {
+		input string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Should get normal plural",
+			args: args{"dog"},
+			want: "dogs",
+		},
+		{
+			name: "Should get irregular plural",
+			args: args{"child"},
+			want: "children",
+		},
+		{
+			name: "Should get added non-countable",
+			args: args{"sms"},
+			want: "sms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Plural(tt.args.input); got != tt.want {
+				t.Errorf("Plural() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestEntityName(t *testing.T) {
 	type args struct {
 		input string